@@ -6,16 +6,33 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"maps"
 	"net/http"
+	"os"
+	"slices"
+	"time"
 
 	"github.com/google/go-github/v90/github"
 )
 
 // Action performs the semver tag sync.
 type Action struct {
-	client GitHubClient
-	config Config
-	log    *slog.Logger
+	client  GitHubClient
+	config  Config
+	log     *slog.Logger
+	out     io.Writer
+	now     func() time.Time
+	moves   []historyEntry
+	planned []plannedChange
+	scheme  VersionScheme
+	filter  *tagFilter
+
+	normalized map[string]bool        // Lenient tags already warned about
+	pinned     map[string]*tagWithSHA // Pinned releases found while collecting tags
+	members    floatingMembers        // Releases per group found while collecting tags
+	floating   map[string]bool        // Names of the floating tags of the listed releases
+	releases   *releaseIndex          // Releases guarding patch-level tags, loaded on demand
+	invalid    error                  // Invalid scheme or filter configuration, returned by Run
 }
 
 // NewAction creates a new Action instance.
@@ -23,15 +40,65 @@ func NewAction(client GitHubClient, config Config, log *slog.Logger) *Action {
 	if log == nil {
 		log = slog.New(slog.NewTextHandler(io.Discard, nil))
 	}
-	return &Action{
+	a := &Action{
 		client: client,
 		config: config,
 		log:    log,
+		out:    os.Stdout,
+		now:    time.Now,
 	}
+	// The scheme and the sync-all filter are checked by Config.Validate. If that was skipped, an
+	// invalid one fails Run instead of falling back to a scheme or filter that selects other tags.
+	a.scheme, a.invalid = newVersionScheme(config)
+	if a.invalid == nil {
+		a.filter, a.invalid = newTagFilter(config.IncludeTags, config.ExcludeTags, config.VersionConstraint)
+	}
+	return a
+}
+
+// parseVersion parses a release tag using the configured version scheme.
+func (a *Action) parseVersion(tag string) (*SemVer, error) {
+	sv, err := a.scheme.Parse(tag)
+	if err != nil {
+		return nil, err
+	}
+	if a.config.BuildMetadata == buildMetadataIgnore && hasBuildMetadata(sv) {
+		return nil, fmt.Errorf("%w: %s", errBuildMetadataIgnored, tag)
+	}
+	if a.isFloatingRef(tag, sv) {
+		return nil, fmt.Errorf("%w: %s", errFloatingRef, tag)
+	}
+	if a.config.LenientSemVer {
+		a.warnNormalized(sv)
+	}
+	return sv, nil
+}
+
+// greaterThan reports whether x is a higher version than y in the configured version scheme.
+func (a *Action) greaterThan(x, y *SemVer) bool {
+	return a.scheme.Compare(x, y) > 0
 }
 
 // Run executes the action.
 func (a *Action) Run(ctx context.Context) error {
+	if a.invalid != nil {
+		return a.invalid
+	}
+	if a.config.ShowHistory {
+		return a.runHistory(ctx)
+	}
+	if a.config.GCPrereleases {
+		return a.runGC(ctx)
+	}
+	if a.config.Verify {
+		return a.runVerify(ctx)
+	}
+	if a.config.Inspect {
+		return a.runInspect(ctx)
+	}
+	if a.config.Explain != "" {
+		return a.runExplain(ctx)
+	}
 	if a.config.SyncAllTags {
 		return a.runAll(ctx)
 	}
@@ -41,6 +108,8 @@ func (a *Action) Run(ctx context.Context) error {
 		slog.String("ref", a.config.GitRef),
 		slog.Bool("sync_major", a.config.SyncMajor),
 		slog.Bool("sync_minor", a.config.SyncMinor),
+		slog.Bool("sync_latest", a.config.SyncLatest),
+		slog.Bool("sync_channels", a.config.SyncChannels),
 		slog.Bool("skip_prereleases", a.config.SkipPrereleases),
 		slog.Bool("dry_run", a.config.DryRun),
 	)
@@ -61,7 +130,19 @@ func (a *Action) Run(ctx context.Context) error {
 	)
 
 	// Parse semantic version
-	semver, err := ParseSemVer(tag)
+	semver, err := a.parseVersion(tag)
+	if errors.Is(err, errBuildMetadataIgnored) {
+		a.log.Info("Skipping tag with build metadata",
+			slog.String("tag", tag),
+		)
+		return nil
+	}
+	if errors.Is(err, errFloatingRef) {
+		a.log.Info("Skipping floating tag maintained by the action",
+			slog.String("tag", tag),
+		)
+		return nil
+	}
 	if err != nil {
 		a.log.Error("Failed to parse semantic version",
 			slog.String("tag", tag),
@@ -75,16 +156,25 @@ func (a *Action) Run(ctx context.Context) error {
 		slog.String("major", semver.Major),
 		slog.String("minor", semver.Minor),
 		slog.String("patch", semver.Patch),
+		slog.String("revision", semver.Revision),
 		slog.Bool("is_prerelease", semver.IsPrerelease),
 		slog.String("suffix", semver.Suffix),
 	)
 
-	// Skip prereleases if configured
-	if semver.IsPrerelease && a.config.SkipPrereleases {
-		a.log.Info("Skipping prerelease tag",
-			slog.String("tag", semver.Full),
-			slog.String("suffix", semver.Suffix),
-		)
+	// Skip prereleases unless a prerelease channel is configured
+	levels := a.eligibleLevels(semver)
+	cleanup := a.config.CleanupChannels != "" && !semver.IsPrerelease
+	if len(levels) == 0 && !cleanup {
+		if semver.IsPrerelease {
+			a.log.Info("Skipping prerelease tag",
+				slog.String("tag", semver.Full),
+				slog.String("suffix", semver.Suffix),
+			)
+		} else {
+			a.log.Info("No floating refs follow this tag, skipping",
+				slog.String("tag", semver.Full),
+			)
+		}
 		return nil
 	}
 
@@ -112,36 +202,57 @@ func (a *Action) Run(ctx context.Context) error {
 
 	var syncErrors []error
 
-	// Sync major version tag
-	if a.config.SyncMajor {
-		majorTag := semver.MajorTag()
-		a.log.Debug("Syncing major version tag",
-			slog.String("major_tag", majorTag),
-			slog.String("commit_sha", a.config.CommitSHA),
-		)
-		if err := a.syncTag(ctx, owner, repo, majorTag); err != nil {
-			a.log.Error("Failed to sync major tag",
-				slog.String("tag", majorTag),
-				slog.String("error", err.Error()),
+	for _, level := range levels {
+		if level.repoWide() {
+			newest, err := a.isNewestInGroup(ctx, owner, repo, level, semver)
+			if err != nil {
+				a.log.Error("Failed to determine newest release",
+					slog.String("level", string(level)),
+					slog.String("error", err.Error()),
+				)
+				syncErrors = append(syncErrors, err)
+				continue
+			}
+			if !newest {
+				a.log.Info("Release is not newer than the current "+string(level)+" release, skipping "+string(level)+" tag",
+					slog.String("tag", semver.Full),
+				)
+				continue
+			}
+		}
+		for _, ref := range a.floatingRefs(level, semver) {
+			name := refDisplayName(ref)
+			kind := refKind(ref)
+			a.log.Debug("Syncing "+string(level)+" version "+kind,
+				slog.String("ref", ref),
+				slog.String("commit_sha", a.config.CommitSHA),
 			)
-			syncErrors = append(syncErrors, fmt.Errorf("failed to sync major tag %s: %w", majorTag, err))
+			err := a.checkPatchLevel(ctx, owner, repo, level, ref, semver.Full)
+			if err == nil {
+				err = a.syncRef(ctx, owner, repo, ref, semver.Full)
+			}
+			if err != nil {
+				a.log.Error("Failed to sync "+string(level)+" "+kind,
+					slog.String(kind, name),
+					slog.String("error", err.Error()),
+				)
+				syncErrors = append(syncErrors, fmt.Errorf("failed to sync %s %s %s: %w", level, kind, name, err))
+			}
 		}
 	}
 
-	// Sync minor version tag
-	if a.config.SyncMinor {
-		minorTag := semver.MinorTag()
-		a.log.Debug("Syncing minor version tag",
-			slog.String("minor_tag", minorTag),
-			slog.String("commit_sha", a.config.CommitSHA),
+	if cleanup {
+		syncErrors = append(syncErrors, a.cleanupChannels(ctx, owner, repo, semver)...)
+	}
+
+	if err := a.writeHistory(ctx, owner, repo); err != nil {
+		a.log.Error("Failed to record history",
+			slog.String("error", err.Error()),
 		)
-		if err := a.syncTag(ctx, owner, repo, minorTag); err != nil {
-			a.log.Error("Failed to sync minor tag",
-				slog.String("tag", minorTag),
-				slog.String("error", err.Error()),
-			)
-			syncErrors = append(syncErrors, fmt.Errorf("failed to sync minor tag %s: %w", minorTag, err))
-		}
+		syncErrors = append(syncErrors, err)
+	}
+	if err := a.writePlanScript(); err != nil {
+		syncErrors = append(syncErrors, err)
 	}
 
 	if len(syncErrors) > 0 {
@@ -152,66 +263,72 @@ func (a *Action) Run(ctx context.Context) error {
 	return nil
 }
 
-// syncTag creates or updates a tag to point to the configured commit SHA.
-func (a *Action) syncTag(ctx context.Context, owner, repo, tag string) error {
-	return a.syncTagToSHA(ctx, owner, repo, tag, a.config.CommitSHA)
+// syncRef creates or updates a ref to point to the configured commit SHA.
+func (a *Action) syncRef(ctx context.Context, owner, repo, ref, release string) error {
+	return a.syncRefToSHA(ctx, owner, repo, ref, a.config.CommitSHA, release)
 }
 
-// syncTagToSHA creates or updates a tag to point to the given commit SHA.
-func (a *Action) syncTagToSHA(ctx context.Context, owner, repo, tag, sha string) error {
-	refName := fmt.Sprintf("tags/%s", tag)
-	fullRefName := fmt.Sprintf("refs/tags/%s", tag)
+// syncRefToSHA creates or updates a tag or branch ref (e.g., "tags/v1") to point to the given commit SHA of release.
+func (a *Action) syncRefToSHA(ctx context.Context, owner, repo, refName, sha, release string) error {
+	if reason := a.holdReason(refName, release); reason != "" {
+		a.logHeld(refName, reason)
+		return nil
+	}
 
-	a.log.Debug("Checking if tag exists",
-		slog.String("tag", tag),
+	fullRefName := "refs/" + refName
+	kind := refKind(refName)
+	name := refDisplayName(refName)
+
+	a.log.Debug("Checking if "+kind+" exists",
+		slog.String(kind, name),
 		slog.String("ref_name", refName),
 	)
 
-	ref, resp, err := a.client.GetRef(ctx, owner, repo, refName)
-	tagExists := err == nil
-
+	currentSHA, refExists, err := a.currentTarget(ctx, owner, repo, refName)
 	if err != nil {
-		if resp == nil || resp.StatusCode != http.StatusNotFound {
-			a.log.Error("Failed to check if tag exists",
-				slog.String("tag", tag),
-				slog.String("error", err.Error()),
-			)
-			return fmt.Errorf("failed to check if tag %s exists: %w", tag, err)
-		}
-		a.log.Debug("Tag does not exist, will create",
-			slog.String("tag", tag),
+		a.log.Error("Failed to check if "+kind+" exists",
+			slog.String(kind, name),
+			slog.String("error", err.Error()),
+		)
+		return fmt.Errorf("failed to check if %s %s exists: %w", kind, name, err)
+	}
+
+	if !refExists {
+		a.log.Debug(capitalize(kind)+" does not exist, will create",
+			slog.String(kind, name),
 		)
 	} else {
-		if ref != nil && ref.Object != nil && ref.Object.GetSHA() == sha {
-			a.log.Info("Tag already points to correct SHA, skipping",
-				slog.String("tag", tag),
+		if currentSHA == sha {
+			a.log.Info(capitalize(kind)+" already points to correct SHA, skipping",
+				slog.String(kind, name),
 				slog.String("commit_sha", sha),
 			)
 			return nil
 		}
-		a.log.Debug("Tag already exists, will update",
-			slog.String("tag", tag),
+		a.log.Debug(capitalize(kind)+" already exists, will update",
+			slog.String(kind, name),
 		)
 	}
 
 	if a.config.DryRun {
-		if tagExists {
-			a.log.Info("[dry-run] Would update tag",
-				slog.String("tag", tag),
+		a.planChange(fullRefName, currentSHA, sha, release)
+		if refExists {
+			a.log.Info("[dry-run] Would update "+kind,
+				slog.String(kind, name),
 				slog.String("commit_sha", sha),
 			)
 		} else {
-			a.log.Info("[dry-run] Would create tag",
-				slog.String("tag", tag),
+			a.log.Info("[dry-run] Would create "+kind,
+				slog.String(kind, name),
 				slog.String("commit_sha", sha),
 			)
 		}
 		return nil
 	}
 
-	if tagExists {
-		a.log.Info("Updating tag",
-			slog.String("tag", tag),
+	if refExists {
+		a.log.Info("Updating "+kind,
+			slog.String(kind, name),
 			slog.String("commit_sha", sha),
 		)
 		updateRef := github.UpdateRef{
@@ -220,14 +337,14 @@ func (a *Action) syncTagToSHA(ctx context.Context, owner, repo, tag, sha string)
 		}
 		_, _, err = a.client.UpdateRef(ctx, owner, repo, refName, updateRef)
 		if err != nil {
-			return fmt.Errorf("failed to update tag %s: %w", tag, err)
+			return fmt.Errorf("failed to update %s %s: %w", kind, name, err)
 		}
-		a.log.Info("Successfully updated tag",
-			slog.String("tag", tag),
+		a.log.Info("Successfully updated "+kind,
+			slog.String(kind, name),
 		)
 	} else {
-		a.log.Info("Creating tag",
-			slog.String("tag", tag),
+		a.log.Info("Creating "+kind,
+			slog.String(kind, name),
 			slog.String("commit_sha", sha),
 		)
 		createRef := github.CreateRef{
@@ -236,13 +353,67 @@ func (a *Action) syncTagToSHA(ctx context.Context, owner, repo, tag, sha string)
 		}
 		_, _, err = a.client.CreateRef(ctx, owner, repo, createRef)
 		if err != nil {
-			return fmt.Errorf("failed to create tag %s: %w", tag, err)
+			return fmt.Errorf("failed to create %s %s: %w", kind, name, err)
 		}
-		a.log.Info("Successfully created tag",
-			slog.String("tag", tag),
+		a.log.Info("Successfully created "+kind,
+			slog.String(kind, name),
 		)
 	}
 
+	a.recordMove(fullRefName, currentSHA, sha, release)
+	return nil
+}
+
+// currentTarget returns the SHA a ref points to and whether it exists.
+func (a *Action) currentTarget(ctx context.Context, owner, repo, refName string) (string, bool, error) {
+	ref, resp, err := a.client.GetRef(ctx, owner, repo, refName)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	return ref.GetObject().GetSHA(), true, nil
+}
+
+// deleteRef deletes a tag or branch ref (e.g., "tags/v1-rc") if it exists.
+func (a *Action) deleteRef(ctx context.Context, owner, repo, refName string) error {
+	kind := refKind(refName)
+	name := refDisplayName(refName)
+
+	ref, resp, err := a.client.GetRef(ctx, owner, repo, refName)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			a.log.Debug(capitalize(kind)+" does not exist, nothing to delete",
+				slog.String(kind, name),
+			)
+			return nil
+		}
+		return fmt.Errorf("failed to check if %s %s exists: %w", kind, name, err)
+	}
+	currentSHA := ref.GetObject().GetSHA()
+
+	if a.config.DryRun {
+		a.planChange("refs/"+refName, currentSHA, "", "")
+		a.log.Info("[dry-run] Would delete "+kind,
+			slog.String(kind, name),
+			slog.String("commit_sha", currentSHA),
+		)
+		return nil
+	}
+
+	a.log.Info("Deleting "+kind,
+		slog.String(kind, name),
+		slog.String("commit_sha", currentSHA),
+	)
+	if _, err := a.client.DeleteRef(ctx, owner, repo, refName); err != nil {
+		return fmt.Errorf("failed to delete %s %s: %w", kind, name, err)
+	}
+	a.log.Info("Successfully deleted "+kind,
+		slog.String(kind, name),
+	)
+
+	a.recordMove("refs/"+refName, currentSHA, "", "")
 	return nil
 }
 
@@ -250,6 +421,20 @@ func (a *Action) syncTagToSHA(ctx context.Context, owner, repo, tag, sha string)
 type tagWithSHA struct {
 	semver *SemVer
 	sha    string
+	date   time.Time // Commit date, fetched on demand by commitDate
+}
+
+// commitDate returns the committer date of the commit a tag points to.
+func (a *Action) commitDate(ctx context.Context, owner, repo string, tag *tagWithSHA) (time.Time, error) {
+	if !tag.date.IsZero() {
+		return tag.date, nil
+	}
+	commit, _, err := a.client.GetCommit(ctx, owner, repo, tag.sha)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get commit of tag %s: %w", tag.semver.Full, err)
+	}
+	tag.date = commit.GetCommitter().GetDate().Time
+	return tag.date, nil
 }
 
 // runAll syncs major/minor tags for all existing semver tags in the repository.
@@ -258,6 +443,8 @@ func (a *Action) runAll(ctx context.Context) error {
 		slog.String("repo", a.config.GitHubRepo),
 		slog.Bool("sync_major", a.config.SyncMajor),
 		slog.Bool("sync_minor", a.config.SyncMinor),
+		slog.Bool("sync_latest", a.config.SyncLatest),
+		slog.Bool("sync_channels", a.config.SyncChannels),
 		slog.Bool("skip_prereleases", a.config.SkipPrereleases),
 		slog.Bool("dry_run", a.config.DryRun),
 	)
@@ -267,14 +454,25 @@ func (a *Action) runAll(ctx context.Context) error {
 		return err
 	}
 
-	majorLatest, minorLatest, err := a.collectLatestTags(ctx, owner, repo)
+	groups, err := a.collectLatestTags(ctx, owner, repo)
 	if err != nil {
 		return err
 	}
 
-	var syncErrors []error
-	syncErrors = append(syncErrors, a.syncTagMap(ctx, owner, repo, majorLatest, "major")...)
-	syncErrors = append(syncErrors, a.syncTagMap(ctx, owner, repo, minorLatest, "minor")...)
+	syncErrors := a.retireLines(ctx, owner, repo, groups)
+	for _, level := range a.levels() {
+		syncErrors = append(syncErrors, a.syncTagMap(ctx, owner, repo, groups[level], level)...)
+	}
+
+	if err := a.writeHistory(ctx, owner, repo); err != nil {
+		a.log.Error("Failed to record history",
+			slog.String("error", err.Error()),
+		)
+		syncErrors = append(syncErrors, err)
+	}
+	if err := a.writePlanScript(); err != nil {
+		syncErrors = append(syncErrors, err)
+	}
 
 	if len(syncErrors) > 0 {
 		return errors.Join(syncErrors...)
@@ -284,27 +482,72 @@ func (a *Action) runAll(ctx context.Context) error {
 	return nil
 }
 
-// collectLatestTags fetches all tags and returns maps of the latest version per major and minor group.
-func (a *Action) collectLatestTags(ctx context.Context, owner, repo string) (majorLatest, minorLatest map[string]*tagWithSHA, err error) {
-	majorLatest = make(map[string]*tagWithSHA)
-	minorLatest = make(map[string]*tagWithSHA)
+// isNewestInGroup reports whether no release in the same group of a repository-wide level has a higher version than sv.
+func (a *Action) isNewestInGroup(ctx context.Context, owner, repo string, level floatingLevel, sv *SemVer) (bool, error) {
+	key := a.groupKey(level, sv)
+
+	var members []*tagWithSHA
+	_, err := a.listAllTags(ctx, owner, repo, func(tag *github.RepositoryTag) {
+		other, err := a.parseVersion(tag.GetName())
+		if err != nil || a.groupKey(level, other) != key {
+			return
+		}
+		members = append(members, &tagWithSHA{semver: other, sha: tag.GetCommit().GetSHA()})
+	})
+	if err != nil {
+		return false, err
+	}
+
+	current := &tagWithSHA{semver: sv, sha: a.config.CommitSHA}
+	for _, other := range members {
+		if other.semver.Full == sv.Full {
+			continue
+		}
+		newer, err := a.beats(ctx, owner, repo, other, current)
+		if err != nil {
+			return false, err
+		}
+		if newer {
+			a.log.Debug("Found newer release",
+				slog.String("tag", sv.Full),
+				slog.String(string(level), other.semver.Full),
+			)
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// floatingGroups maps each floating level to the latest release per group key (e.g., "v1").
+type floatingGroups map[floatingLevel]map[string]*tagWithSHA
 
+// floatingMembers maps each floating level to the releases per group key.
+type floatingMembers map[floatingLevel]map[string][]*tagWithSHA
+
+// add records a release of the group key at level. Recording is skipped if m is nil.
+func (m floatingMembers) add(level floatingLevel, key string, entry *tagWithSHA) {
+	if m == nil {
+		return
+	}
+	if m[level] == nil {
+		m[level] = make(map[string][]*tagWithSHA)
+	}
+	m[level][key] = append(m[level][key], entry)
+}
+
+// listAllTags pages through all repository tags and calls fn for each of them.
+func (a *Action) listAllTags(ctx context.Context, owner, repo string, fn func(tag *github.RepositoryTag)) (int, error) {
 	page := 1
-	totalTags := 0
+	var all []*github.RepositoryTag
 	for {
 		tags, resp, err := a.client.ListTags(ctx, owner, repo, &github.ListOptions{
 			Page:    page,
 			PerPage: 100,
 		})
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to list tags (page %d): %w", page, err)
-		}
-
-		for _, tag := range tags {
-			a.processTag(tag, majorLatest, minorLatest)
+			return len(all), fmt.Errorf("failed to list tags (page %d): %w", page, err)
 		}
-
-		totalTags += len(tags)
+		all = append(all, tags...)
 
 		if resp.NextPage == 0 {
 			break
@@ -312,66 +555,124 @@ func (a *Action) collectLatestTags(ctx context.Context, owner, repo string) (maj
 		page = resp.NextPage
 	}
 
+	// Floating tags are listed along with the releases, so learn their names before parsing any tag.
+	a.floating = a.floatingTagNames(all)
+	for _, tag := range all {
+		fn(tag)
+	}
+	return len(all), nil
+}
+
+// collectLatestTags fetches all tags and returns the latest version per group of every enabled floating level.
+func (a *Action) collectLatestTags(ctx context.Context, owner, repo string) (floatingGroups, error) {
+	groups := make(floatingGroups)
+	for _, level := range a.levels() {
+		groups[level] = make(map[string]*tagWithSHA)
+	}
+
+	var tieErr error
+	a.pinned = make(map[string]*tagWithSHA)
+	a.members = make(floatingMembers)
+	totalTags, err := a.listAllTags(ctx, owner, repo, func(tag *github.RepositoryTag) {
+		a.collectPinned(tag)
+		if err := a.processTag(ctx, owner, repo, tag, groups); err != nil && tieErr == nil {
+			tieErr = err
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if tieErr != nil {
+		return nil, tieErr
+	}
+
 	a.log.Info("Fetched all tags",
 		slog.Int("total_tags", totalTags),
-		slog.Int("major_groups", len(majorLatest)),
-		slog.Int("minor_groups", len(minorLatest)),
+		slog.Int("major_groups", len(groups[levelMajor])),
+		slog.Int("minor_groups", len(groups[levelMinor])),
+		slog.Int("patch_groups", len(groups[levelPatch])),
 	)
-	return majorLatest, minorLatest, nil
+	return groups, nil
 }
 
-// processTag parses a single repository tag and updates the major/minor latest maps if applicable.
-func (a *Action) processTag(tag *github.RepositoryTag, majorLatest, minorLatest map[string]*tagWithSHA) {
+// processTag parses a single repository tag and updates the latest release of each group it belongs to.
+// It only fails if breaking a tie between build metadata variants requires an API call that fails.
+func (a *Action) processTag(ctx context.Context, owner, repo string, tag *github.RepositoryTag, groups floatingGroups) error {
 	name := tag.GetName()
-	sv, err := ParseSemVer(name)
+	sv, err := a.parseVersion(name)
 	if err != nil {
 		a.log.Debug("Skipping non-semver tag", slog.String("tag", name))
-		return
+		return nil
 	}
 
-	if sv.IsPrerelease && a.config.SkipPrereleases {
+	if reason := a.filter.skipReason(sv); reason != "" {
+		a.log.Debug("Skipping filtered tag", slog.String("tag", name), slog.String("reason", reason))
+		return nil
+	}
+
+	if sv.IsPrerelease && len(a.eligibleLevels(sv)) == 0 {
 		a.log.Debug("Skipping prerelease tag", slog.String("tag", name))
-		return
+		return nil
 	}
 
 	sha := tag.GetCommit().GetSHA()
 	if sha == "" {
 		a.log.Debug("Skipping tag with no commit SHA", slog.String("tag", name))
-		return
+		return nil
 	}
 
 	entry := &tagWithSHA{semver: sv, sha: sha}
 
-	if a.config.SyncMajor {
-		majorKey := sv.MajorTag()
-		if existing, ok := majorLatest[majorKey]; !ok || SemVerGreaterThan(sv, existing.semver) {
-			majorLatest[majorKey] = entry
+	for level, latest := range groups {
+		key := a.groupKey(level, sv)
+		if key == "" {
+			continue
 		}
-	}
-
-	if a.config.SyncMinor {
-		minorKey := sv.MinorTag()
-		if existing, ok := minorLatest[minorKey]; !ok || SemVerGreaterThan(sv, existing.semver) {
-			minorLatest[minorKey] = entry
+		a.members.add(level, key, entry)
+		existing, ok := latest[key]
+		if !ok {
+			latest[key] = entry
+			continue
+		}
+		newer, err := a.beats(ctx, owner, repo, entry, existing)
+		if err != nil {
+			return err
+		}
+		if newer {
+			latest[key] = entry
 		}
 	}
+	return nil
 }
 
-// syncTagMap syncs all tags in the given map, returning any errors encountered.
-func (a *Action) syncTagMap(ctx context.Context, owner, repo string, tagMap map[string]*tagWithSHA, label string) []error {
+// syncTagMap syncs the floating refs of every group in the given map, returning any errors encountered.
+func (a *Action) syncTagMap(ctx context.Context, owner, repo string, tagMap map[string]*tagWithSHA, level floatingLevel) []error {
 	var errs []error
-	for tagName, entry := range tagMap {
-		a.log.Debug("Syncing "+label+" tag",
-			slog.String("tag", tagName),
-			slog.String("from_version", entry.semver.Full),
-			slog.String("commit_sha", entry.sha),
-		)
-		if err := a.syncTagToSHA(ctx, owner, repo, tagName, entry.sha); err != nil {
-			a.log.Error("Failed to sync "+label+" tag",
-				slog.String("tag", tagName),
-				slog.String("error", err.Error()),
+	for _, key := range slices.Sorted(maps.Keys(tagMap)) {
+		for _, ref := range a.floatingRefs(level, tagMap[key].semver) {
+			name := refDisplayName(ref)
+			kind := refKind(ref)
+			entry, err := a.targetRelease(ref, tagMap[key])
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to sync %s %s %s: %w", level, kind, name, err))
+				continue
+			}
+			a.log.Debug("Syncing "+string(level)+" "+kind,
+				slog.String(kind, name),
+				slog.String("from_version", entry.semver.Full),
+				slog.String("commit_sha", entry.sha),
 			)
-			errs = append(errs, fmt.Errorf("failed to sync %s tag %s: %w", label, tagName, err))
+			err = a.checkPatchLevel(ctx, owner, repo, level, ref, entry.semver.Full)
+			if err == nil {
+				err = a.syncRefToSHA(ctx, owner, repo, ref, entry.sha, entry.semver.Full)
+			}
+			if err != nil {
+				a.log.Error("Failed to sync "+string(level)+" "+kind,
+					slog.String(kind, name),
+					slog.String("error", err.Error()),
+				)
+				errs = append(errs, fmt.Errorf("failed to sync %s %s %s: %w", level, kind, name, err))
+			}
 		}
 	}
 	return errs
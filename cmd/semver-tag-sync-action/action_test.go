@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"slices"
 	"testing"
 
 	"github.com/google/go-github/v90/github"
@@ -11,10 +12,23 @@ import (
 
 // mockGitHubClient is a mock implementation of GitHubClient for testing.
 type mockGitHubClient struct {
-	getRefFunc    func(ctx context.Context, owner, repo, ref string) (*github.Reference, *github.Response, error)
-	createRefFunc func(ctx context.Context, owner, repo string, ref github.CreateRef) (*github.Reference, *github.Response, error)
-	updateRefFunc func(ctx context.Context, owner, repo, ref string, updateRef github.UpdateRef) (*github.Reference, *github.Response, error)
-	listTagsFunc  func(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryTag, *github.Response, error)
+	getRefFunc        func(ctx context.Context, owner, repo, ref string) (*github.Reference, *github.Response, error)
+	createRefFunc     func(ctx context.Context, owner, repo string, ref github.CreateRef) (*github.Reference, *github.Response, error)
+	updateRefFunc     func(ctx context.Context, owner, repo, ref string, updateRef github.UpdateRef) (*github.Reference, *github.Response, error)
+	deleteRefFunc     func(ctx context.Context, owner, repo, ref string) (*github.Response, error)
+	listTagsFunc      func(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryTag, *github.Response, error)
+	listReleasesFunc  func(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryRelease, *github.Response, error)
+	getCommitFunc     func(ctx context.Context, owner, repo, sha string) (*github.Commit, *github.Response, error)
+	getBlobRawFunc    func(ctx context.Context, owner, repo, sha string) ([]byte, *github.Response, error)
+	getContentsFunc   func(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error)
+	createFileFunc    func(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentFileOptions) (*github.RepositoryContentResponse, *github.Response, error)
+	updateFileFunc    func(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentFileOptions) (*github.RepositoryContentResponse, *github.Response, error)
+	createTreeFunc    func(ctx context.Context, owner, repo, baseTree string, entries []*github.TreeEntry) (*github.Tree, *github.Response, error)
+	createCommitFunc  func(ctx context.Context, owner, repo string, commit github.Commit, opts *github.CreateCommitOptions) (*github.Commit, *github.Response, error)
+	listIssuesFunc    func(ctx context.Context, owner, repo string, opts *github.IssueListByRepoOptions) ([]*github.Issue, *github.Response, error)
+	createIssueFunc   func(ctx context.Context, owner, repo string, issue github.CreateIssueRequest) (*github.Issue, *github.Response, error)
+	updateIssueFunc   func(ctx context.Context, owner, repo string, number int, issue github.UpdateIssueRequest) (*github.Issue, *github.Response, error)
+	createCommentFunc func(ctx context.Context, owner, repo string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error)
 }
 
 func (m *mockGitHubClient) GetRef(ctx context.Context, owner, repo, ref string) (*github.Reference, *github.Response, error) {
@@ -38,6 +52,13 @@ func (m *mockGitHubClient) UpdateRef(ctx context.Context, owner, repo, ref strin
 	return &github.Reference{}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
 }
 
+func (m *mockGitHubClient) DeleteRef(ctx context.Context, owner, repo, ref string) (*github.Response, error) {
+	if m.deleteRefFunc != nil {
+		return m.deleteRefFunc(ctx, owner, repo, ref)
+	}
+	return &github.Response{Response: &http.Response{StatusCode: http.StatusNoContent}}, nil
+}
+
 func (m *mockGitHubClient) ListTags(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryTag, *github.Response, error) {
 	if m.listTagsFunc != nil {
 		return m.listTagsFunc(ctx, owner, repo, opts)
@@ -45,6 +66,90 @@ func (m *mockGitHubClient) ListTags(ctx context.Context, owner, repo string, opt
 	return nil, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
 }
 
+func (m *mockGitHubClient) ListReleases(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryRelease, *github.Response, error) {
+	if m.listReleasesFunc != nil {
+		return m.listReleasesFunc(ctx, owner, repo, opts)
+	}
+	return nil, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+}
+
+func (m *mockGitHubClient) GetCommit(ctx context.Context, owner, repo, sha string) (*github.Commit, *github.Response, error) {
+	if m.getCommitFunc != nil {
+		return m.getCommitFunc(ctx, owner, repo, sha)
+	}
+	return &github.Commit{SHA: github.Ptr(sha)}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+}
+
+func (m *mockGitHubClient) GetBlobRaw(ctx context.Context, owner, repo, sha string) ([]byte, *github.Response, error) {
+	if m.getBlobRawFunc != nil {
+		return m.getBlobRawFunc(ctx, owner, repo, sha)
+	}
+	return nil, &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, errors.New("not found")
+}
+
+func (m *mockGitHubClient) GetContents(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error) {
+	if m.getContentsFunc != nil {
+		return m.getContentsFunc(ctx, owner, repo, path, opts)
+	}
+	return nil, nil, &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, errors.New("not found")
+}
+
+func (m *mockGitHubClient) CreateFile(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentFileOptions) (*github.RepositoryContentResponse, *github.Response, error) {
+	if m.createFileFunc != nil {
+		return m.createFileFunc(ctx, owner, repo, path, opts)
+	}
+	return &github.RepositoryContentResponse{}, &github.Response{Response: &http.Response{StatusCode: http.StatusCreated}}, nil
+}
+
+func (m *mockGitHubClient) UpdateFile(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentFileOptions) (*github.RepositoryContentResponse, *github.Response, error) {
+	if m.updateFileFunc != nil {
+		return m.updateFileFunc(ctx, owner, repo, path, opts)
+	}
+	return &github.RepositoryContentResponse{}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+}
+
+func (m *mockGitHubClient) CreateTree(ctx context.Context, owner, repo, baseTree string, entries []*github.TreeEntry) (*github.Tree, *github.Response, error) {
+	if m.createTreeFunc != nil {
+		return m.createTreeFunc(ctx, owner, repo, baseTree, entries)
+	}
+	return &github.Tree{SHA: github.Ptr("tree")}, &github.Response{Response: &http.Response{StatusCode: http.StatusCreated}}, nil
+}
+
+func (m *mockGitHubClient) CreateCommit(ctx context.Context, owner, repo string, commit github.Commit, opts *github.CreateCommitOptions) (*github.Commit, *github.Response, error) {
+	if m.createCommitFunc != nil {
+		return m.createCommitFunc(ctx, owner, repo, commit, opts)
+	}
+	return &github.Commit{SHA: github.Ptr("commit")}, &github.Response{Response: &http.Response{StatusCode: http.StatusCreated}}, nil
+}
+
+func (m *mockGitHubClient) ListIssues(ctx context.Context, owner, repo string, opts *github.IssueListByRepoOptions) ([]*github.Issue, *github.Response, error) {
+	if m.listIssuesFunc != nil {
+		return m.listIssuesFunc(ctx, owner, repo, opts)
+	}
+	return nil, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+}
+
+func (m *mockGitHubClient) CreateIssue(ctx context.Context, owner, repo string, issue github.CreateIssueRequest) (*github.Issue, *github.Response, error) {
+	if m.createIssueFunc != nil {
+		return m.createIssueFunc(ctx, owner, repo, issue)
+	}
+	return &github.Issue{Number: github.Ptr(1)}, &github.Response{Response: &http.Response{StatusCode: http.StatusCreated}}, nil
+}
+
+func (m *mockGitHubClient) UpdateIssue(ctx context.Context, owner, repo string, number int, issue github.UpdateIssueRequest) (*github.Issue, *github.Response, error) {
+	if m.updateIssueFunc != nil {
+		return m.updateIssueFunc(ctx, owner, repo, number, issue)
+	}
+	return &github.Issue{Number: github.Ptr(number)}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+}
+
+func (m *mockGitHubClient) CreateIssueComment(ctx context.Context, owner, repo string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+	if m.createCommentFunc != nil {
+		return m.createCommentFunc(ctx, owner, repo, number, comment)
+	}
+	return &github.IssueComment{}, &github.Response{Response: &http.Response{StatusCode: http.StatusCreated}}, nil
+}
+
 func TestActionRun_CreateNewTags(t *testing.T) {
 	var createdRefs []string
 	mock := &mockGitHubClient{
@@ -636,3 +741,246 @@ func TestActionRunAll_DryRun(t *testing.T) {
 		t.Fatalf("Run() error = %v", err)
 	}
 }
+
+func TestActionRun_SyncLatest(t *testing.T) {
+	tests := []struct {
+		name       string
+		gitRef     string
+		wantLatest bool
+	}{
+		{"highest release moves latest", "refs/tags/v2.1.0", true},
+		{"older major does not move latest", "refs/tags/v1.9.0", false},
+		{"prerelease does not move latest", "refs/tags/v3.0.0-rc.1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var createdRefs []string
+			mock := &mockGitHubClient{
+				listTagsFunc: func(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryTag, *github.Response, error) {
+					return []*github.RepositoryTag{
+						makeTag("v1.9.0", "sha190"),
+						makeTag("v2.0.0", "sha200"),
+						makeTag("v2.1.0", "sha210"),
+						makeTag("v3.0.0-rc.1", "sha300rc1"),
+					}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+				},
+				createRefFunc: func(ctx context.Context, owner, repo string, ref github.CreateRef) (*github.Reference, *github.Response, error) {
+					createdRefs = append(createdRefs, ref.Ref)
+					return &github.Reference{}, &github.Response{Response: &http.Response{StatusCode: http.StatusCreated}}, nil
+				},
+			}
+
+			config := Config{
+				GitHubRepo: "owner/repo",
+				GitRef:     tt.gitRef,
+				CommitSHA:  "abc123",
+				SyncLatest: true,
+				LatestTag:  "latest",
+			}
+
+			action := NewAction(mock, config, nil)
+			if err := action.Run(context.Background()); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+
+			gotLatest := slices.Contains(createdRefs, "refs/tags/latest")
+			if gotLatest != tt.wantLatest {
+				t.Errorf("latest created = %v, want %v (created %v)", gotLatest, tt.wantLatest, createdRefs)
+			}
+		})
+	}
+}
+
+func TestActionRunAll_SyncLatest(t *testing.T) {
+	var createdRefs []string
+	mock := &mockGitHubClient{
+		listTagsFunc: func(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryTag, *github.Response, error) {
+			return []*github.RepositoryTag{
+				makeTag("v2.1.0", "sha210"),
+				makeTag("v1.9.0", "sha190"),
+				makeTag("v3.0.0-rc.1", "sha300rc1"),
+			}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+		},
+		createRefFunc: func(ctx context.Context, owner, repo string, ref github.CreateRef) (*github.Reference, *github.Response, error) {
+			createdRefs = append(createdRefs, ref.Ref+"="+ref.SHA)
+			return &github.Reference{}, &github.Response{Response: &http.Response{StatusCode: http.StatusCreated}}, nil
+		},
+	}
+
+	config := Config{
+		GitHubRepo:      "owner/repo",
+		SyncAllTags:     true,
+		SyncLatest:      true,
+		SkipPrereleases: false,
+		LatestTag:       "stable",
+	}
+
+	action := NewAction(mock, config, nil)
+	if err := action.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if !slices.Equal(createdRefs, []string{"refs/tags/stable=sha210"}) {
+		t.Errorf("created refs = %v, want [refs/tags/stable=sha210]", createdRefs)
+	}
+}
+
+func TestActionRun_PrereleaseChannels(t *testing.T) {
+	var createdRefs []string
+	mock := &mockGitHubClient{
+		listTagsFunc: func(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryTag, *github.Response, error) {
+			return []*github.RepositoryTag{
+				makeTag("v1.9.0", "sha190"),
+				makeTag("v2.0.0-rc.2", "sha200rc2"),
+			}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+		},
+		createRefFunc: func(ctx context.Context, owner, repo string, ref github.CreateRef) (*github.Reference, *github.Response, error) {
+			createdRefs = append(createdRefs, ref.Ref)
+			return &github.Reference{}, &github.Response{Response: &http.Response{StatusCode: http.StatusCreated}}, nil
+		},
+	}
+
+	config := Config{
+		GitHubRepo:      "owner/repo",
+		GitRef:          "refs/tags/v2.0.0-rc.3",
+		CommitSHA:       "abc123",
+		SyncMajor:       true,
+		SyncMinor:       true,
+		SkipPrereleases: true,
+		SyncChannels:    true,
+		SyncNext:        true,
+		NextTag:         "next",
+	}
+
+	action := NewAction(mock, config, nil)
+	if err := action.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	want := []string{"refs/tags/v2-rc", "refs/tags/v2.0-rc", "refs/tags/next"}
+	if !slices.Equal(createdRefs, want) {
+		t.Errorf("created refs = %v, want %v", createdRefs, want)
+	}
+}
+
+func TestActionRunAll_PrereleaseChannels(t *testing.T) {
+	var createdRefs []string
+	mock := &mockGitHubClient{
+		listTagsFunc: func(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryTag, *github.Response, error) {
+			return []*github.RepositoryTag{
+				makeTag("v1.0.0", "sha100"),
+				makeTag("v2.0.0-beta.1", "sha200b1"),
+				makeTag("v2.0.0-rc.1", "sha200rc1"),
+				makeTag("v2.0.0-rc.2", "sha200rc2"),
+				makeTag("v1.1.0-rc.1", "sha110rc1"),
+			}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+		},
+		createRefFunc: func(ctx context.Context, owner, repo string, ref github.CreateRef) (*github.Reference, *github.Response, error) {
+			createdRefs = append(createdRefs, ref.Ref+"="+ref.SHA)
+			return &github.Reference{}, &github.Response{Response: &http.Response{StatusCode: http.StatusCreated}}, nil
+		},
+	}
+
+	config := Config{
+		GitHubRepo:      "owner/repo",
+		SyncAllTags:     true,
+		SyncMajor:       true,
+		SkipPrereleases: true,
+		SyncChannels:    true,
+		SyncNext:        true,
+	}
+
+	action := NewAction(mock, config, nil)
+	if err := action.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	want := []string{
+		"refs/tags/next=sha200rc2",
+		"refs/tags/v1-rc=sha110rc1",
+		"refs/tags/v1=sha100",
+		"refs/tags/v2-beta=sha200b1",
+		"refs/tags/v2-rc=sha200rc2",
+	}
+	slices.Sort(createdRefs)
+	if !slices.Equal(createdRefs, want) {
+		t.Errorf("created refs = %v, want %v", createdRefs, want)
+	}
+}
+
+func TestActionRun_FourPartVersions(t *testing.T) {
+	var createdRefs []string
+	mock := &mockGitHubClient{
+		createRefFunc: func(ctx context.Context, owner, repo string, ref github.CreateRef) (*github.Reference, *github.Response, error) {
+			createdRefs = append(createdRefs, ref.Ref)
+			return &github.Reference{}, &github.Response{Response: &http.Response{StatusCode: http.StatusCreated}}, nil
+		},
+	}
+
+	config := Config{
+		GitHubRepo:       "owner/repo",
+		GitRef:           "refs/tags/v1.2.3.4",
+		CommitSHA:        "abc123",
+		SyncMajor:        true,
+		SyncMinor:        true,
+		FourPartVersions: true,
+		SyncPatch:        true,
+		SkipPrereleases:  true,
+	}
+
+	action := NewAction(mock, config, nil)
+	if err := action.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	want := []string{"refs/tags/v1", "refs/tags/v1.2", "refs/tags/v1.2.3"}
+	if !slices.Equal(createdRefs, want) {
+		t.Errorf("created refs = %v, want %v", createdRefs, want)
+	}
+}
+
+func TestActionRunAll_FourPartVersions(t *testing.T) {
+	var createdRefs []string
+	mock := &mockGitHubClient{
+		listTagsFunc: func(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryTag, *github.Response, error) {
+			return []*github.RepositoryTag{
+				makeTag("v1.2.3.9", "sha1239"),
+				makeTag("v1.2.3.10", "sha12310"),
+				makeTag("v1.2.4.1", "sha1241"),
+				makeTag("v1.2.4.2-rc.1", "sha1242rc1"),
+				makeTag("v1.2.3", "sha12310"),
+			}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+		},
+		createRefFunc: func(ctx context.Context, owner, repo string, ref github.CreateRef) (*github.Reference, *github.Response, error) {
+			createdRefs = append(createdRefs, ref.Ref+"="+ref.SHA)
+			return &github.Reference{}, &github.Response{Response: &http.Response{StatusCode: http.StatusCreated}}, nil
+		},
+	}
+
+	config := Config{
+		GitHubRepo:       "owner/repo",
+		SyncAllTags:      true,
+		SyncMajor:        true,
+		SyncMinor:        true,
+		FourPartVersions: true,
+		SyncPatch:        true,
+		SkipPrereleases:  true,
+	}
+
+	action := NewAction(mock, config, nil)
+	if err := action.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	want := []string{
+		"refs/tags/v1.2.3=sha12310",
+		"refs/tags/v1.2.4=sha1241",
+		"refs/tags/v1.2=sha1241",
+		"refs/tags/v1=sha1241",
+	}
+	slices.Sort(createdRefs)
+	if !slices.Equal(createdRefs, want) {
+		t.Errorf("created refs = %v, want %v", createdRefs, want)
+	}
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultCalVerFormat is the calendar versioning format used when none is configured.
+const defaultCalVerFormat = "YYYY.MM.MICRO"
+
+// CalVer format tokens, see https://calver.org.
+const (
+	calverYYYY  = "YYYY"  // Full year, e.g., 2026
+	calverYY    = "YY"    // Short year without padding, e.g., 6 or 26
+	calverZeroY = "0Y"    // Zero-padded short year, e.g., 06 or 26
+	calverMM    = "MM"    // Month without padding, e.g., 1 or 10
+	calverZeroM = "0M"    // Zero-padded month, e.g., 01 or 10
+	calverWW    = "WW"    // Week of the year without padding, e.g., 1 or 42
+	calverZeroW = "0W"    // Zero-padded week of the year, e.g., 01 or 42
+	calverDD    = "DD"    // Day of the month without padding, e.g., 3 or 31
+	calverZeroD = "0D"    // Zero-padded day of the month, e.g., 03 or 31
+	calverMICRO = "MICRO" // Incrementing release number within the period
+)
+
+// calverFormat is a parsed calendar versioning format such as YYYY.MM.MICRO. Versions are
+// ordered and grouped numerically like semantic versions, which matches calendar order.
+type calverFormat struct {
+	semverScheme
+	raw    string
+	tokens []string
+}
+
+// parseCalVerFormat parses and validates a CalVer format. The first segment must be a year,
+// the second a month or week, and the optional third a day (with a month) or MICRO.
+func parseCalVerFormat(format string) (*calverFormat, error) {
+	tokens := strings.Split(format, ".")
+	if len(tokens) < 2 || len(tokens) > 3 {
+		return nil, fmt.Errorf("invalid calver format %q (expected 2 or 3 segments, e.g., YYYY.MM.MICRO)", format)
+	}
+	switch tokens[0] {
+	case calverYYYY, calverYY, calverZeroY:
+	default:
+		return nil, fmt.Errorf("invalid calver format %q (first segment must be YYYY, YY or 0Y)", format)
+	}
+	switch tokens[1] {
+	case calverMM, calverZeroM, calverWW, calverZeroW:
+	default:
+		return nil, fmt.Errorf("invalid calver format %q (second segment must be MM, 0M, WW or 0W)", format)
+	}
+	if len(tokens) == 3 {
+		switch tokens[2] {
+		case calverMICRO:
+		case calverDD, calverZeroD:
+			if tokens[1] == calverWW || tokens[1] == calverZeroW {
+				return nil, fmt.Errorf("invalid calver format %q (a day requires a month, not a week)", format)
+			}
+		default:
+			return nil, fmt.Errorf("invalid calver format %q (third segment must be DD, 0D or MICRO)", format)
+		}
+	}
+	return &calverFormat{raw: format, tokens: tokens}, nil
+}
+
+// Parse parses a CalVer tag such as v2026.10.3 or 2026.10 into a version whose major,
+// minor and patch components hold the year, month (or week) and day (or micro) segments.
+func (f *calverFormat) Parse(tag string) (*SemVer, error) {
+	prefix := ""
+	rest := tag
+	if strings.HasPrefix(rest, "v") {
+		prefix = "v"
+		rest = rest[1:]
+	}
+
+	core, suffix := rest, ""
+	if i := strings.IndexAny(rest, "-+"); i >= 0 {
+		core, suffix = rest[:i], rest[i:]
+	}
+
+	segments := strings.Split(core, ".")
+	if len(segments) != len(f.tokens) {
+		return nil, fmt.Errorf("tag %q does not match calendar versioning format %s", tag, f.raw)
+	}
+
+	values := make([]int, len(segments))
+	for i, segment := range segments {
+		value, err := parseCalVerSegment(f.tokens[i], segment)
+		if err != nil {
+			return nil, fmt.Errorf("tag %q does not match calendar versioning format %s: %w", tag, f.raw, err)
+		}
+		values[i] = value
+	}
+
+	if len(f.tokens) == 3 && (f.tokens[2] == calverDD || f.tokens[2] == calverZeroD) {
+		year := values[0]
+		if f.tokens[0] != calverYYYY {
+			year += 2000
+		}
+		month, day := values[1], values[2]
+		if date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC); date.Day() != day {
+			return nil, fmt.Errorf("tag %q is not a valid calendar date (%04d-%02d-%02d)", tag, year, month, day)
+		}
+	}
+
+	sv := &SemVer{
+		Prefix:       prefix,
+		Major:        segments[0],
+		Minor:        segments[1],
+		Suffix:       suffix,
+		Full:         tag,
+		IsPrerelease: strings.HasPrefix(suffix, "-"),
+	}
+	if len(segments) == 3 {
+		sv.Patch = segments[2]
+	}
+	sv.Channel = prereleaseChannel(sv)
+	return sv, nil
+}
+
+// calverSamples are sample values of the format tokens, e.g. v2026.10.1 for YYYY.MM.MICRO.
+var calverSamples = map[string]string{
+	calverYYYY: "2026", calverYY: "26", calverZeroY: "26",
+	calverMM: "10", calverZeroM: "10", calverWW: "42", calverZeroW: "42",
+	calverDD: "3", calverZeroD: "03", calverMICRO: "1",
+}
+
+// Sample returns a release of the format, such as v2026.10.1 for YYYY.MM.MICRO.
+func (f *calverFormat) Sample() *SemVer {
+	values := make([]string, len(f.tokens))
+	for i, token := range f.tokens {
+		values[i] = calverSamples[token]
+	}
+	sv, _ := f.Parse("v" + strings.Join(values, "."))
+	return sv
+}
+
+// parseCalVerSegment parses a single version segment and checks it against its format token.
+func parseCalVerSegment(token, segment string) (int, error) {
+	if segment == "" || strings.Trim(segment, "0123456789") != "" {
+		return 0, fmt.Errorf("segment %q is not numeric", segment)
+	}
+	padded := strings.HasPrefix(token, "0")
+	if !padded && token != calverYYYY && len(segment) > 1 && segment[0] == '0' {
+		return 0, fmt.Errorf("segment %q for %s must not be zero-padded", segment, token)
+	}
+	if padded && len(segment) < 2 {
+		return 0, fmt.Errorf("segment %q for %s must be zero-padded", segment, token)
+	}
+	value, err := strconv.Atoi(segment)
+	if err != nil {
+		return 0, fmt.Errorf("segment %q is out of range", segment)
+	}
+
+	var lo, hi int
+	switch token {
+	case calverYYYY:
+		if len(segment) != 4 {
+			return 0, fmt.Errorf("year %q must have four digits", segment)
+		}
+		lo, hi = 1, 9999
+	case calverYY, calverZeroY:
+		lo, hi = 0, 999
+	case calverMM, calverZeroM:
+		lo, hi = 1, 12
+	case calverWW, calverZeroW:
+		lo, hi = 1, 53
+	case calverDD, calverZeroD:
+		lo, hi = 1, 31
+	default:
+		return value, nil
+	}
+	if value < lo || value > hi {
+		return 0, fmt.Errorf("%s value %d is out of range %d-%d", token, value, lo, hi)
+	}
+	return value, nil
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/google/go-github/v90/github"
+)
+
+func TestParseCalVerFormat(t *testing.T) {
+	tests := []struct {
+		format  string
+		wantErr bool
+	}{
+		{"YYYY.MM.MICRO", false},
+		{"YYYY.0M.0D", false},
+		{"YY.0M", false},
+		{"0Y.WW.MICRO", false},
+		{"YYYY", true},
+		{"MM.YYYY", true},
+		{"YYYY.WW.DD", true},
+		{"YYYY.MM.MICRO.MICRO", true},
+		{"YYYY.MM.PATCH", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			_, err := parseCalVerFormat(tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseCalVerFormat(%q) error = %v, wantErr %v", tt.format, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCalVerParse(t *testing.T) {
+	tests := []struct {
+		name      string
+		format    string
+		tag       string
+		wantMajor string
+		wantMinor string
+		wantPatch string
+		wantErr   bool
+	}{
+		{"full year with micro", "YYYY.MM.MICRO", "v2026.10.3", "2026", "10", "3", false},
+		{"without prefix", "YYYY.MM.MICRO", "2026.10.3", "2026", "10", "3", false},
+		{"two segments", "YY.0M", "26.09", "26", "09", "", false},
+		{"prerelease", "YYYY.MM.MICRO", "v2026.10.0-rc.1", "2026", "10", "0", false},
+		{"valid date", "YYYY.0M.0D", "2024.02.29", "2024", "02", "29", false},
+		{"invalid date", "YYYY.0M.0D", "2026.02.30", "", "", "", true},
+		{"month out of range", "YYYY.MM.MICRO", "2026.13.1", "", "", "", true},
+		{"padding required", "YY.0M", "26.9", "", "", "", true},
+		{"padding not allowed", "YYYY.MM.MICRO", "2026.09.1", "", "", "", true},
+		{"short full year", "YYYY.MM.MICRO", "26.9.1", "", "", "", true},
+		{"wrong segment count", "YYYY.MM.MICRO", "2026.10", "", "", "", true},
+		{"semver is not calver", "YYYY.MM.MICRO", "v1.2", "", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, err := parseCalVerFormat(tt.format)
+			if err != nil {
+				t.Fatalf("parseCalVerFormat() error = %v", err)
+			}
+			sv, err := format.Parse(tt.tag)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse(%q) error = %v, wantErr %v", tt.tag, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if sv.Major != tt.wantMajor || sv.Minor != tt.wantMinor || sv.Patch != tt.wantPatch {
+				t.Errorf("Parse(%q) = %s/%s/%s, want %s/%s/%s", tt.tag, sv.Major, sv.Minor, sv.Patch, tt.wantMajor, tt.wantMinor, tt.wantPatch)
+			}
+		})
+	}
+}
+
+func TestActionRunAll_CalVer(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		tags   []*github.RepositoryTag
+		want   []string
+	}{
+		{
+			name:   "year and year.month",
+			format: "YYYY.MM.MICRO",
+			tags: []*github.RepositoryTag{
+				makeTag("v2025.12.4", "sha25124"),
+				makeTag("v2026.9.10", "sha26910"),
+				makeTag("v2026.10.1", "sha26101"),
+				makeTag("v2026.10.3", "sha26103"),
+				makeTag("v1.2.3", "sha123"),
+			},
+			want: []string{
+				"refs/tags/v2025.12=sha25124",
+				"refs/tags/v2025=sha25124",
+				"refs/tags/v2026.10=sha26103",
+				"refs/tags/v2026.9=sha26910",
+				"refs/tags/v2026=sha26103",
+			},
+		},
+		{
+			name:   "two segments only float the year",
+			format: "YY.0M",
+			tags: []*github.RepositoryTag{
+				makeTag("26.09", "sha2609"),
+				makeTag("26.10", "sha2610"),
+			},
+			want: []string{"refs/tags/26=sha2610"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var createdRefs []string
+			mock := &mockGitHubClient{
+				listTagsFunc: func(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryTag, *github.Response, error) {
+					return tt.tags, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+				},
+				createRefFunc: func(ctx context.Context, owner, repo string, ref github.CreateRef) (*github.Reference, *github.Response, error) {
+					createdRefs = append(createdRefs, ref.Ref+"="+ref.SHA)
+					return &github.Reference{}, &github.Response{Response: &http.Response{StatusCode: http.StatusCreated}}, nil
+				},
+			}
+
+			config := Config{
+				GitHubRepo:    "owner/repo",
+				SyncAllTags:   true,
+				SyncMajor:     true,
+				SyncMinor:     true,
+				VersionScheme: schemeCalVer,
+				CalVerFormat:  tt.format,
+			}
+
+			action := NewAction(mock, config, nil)
+			if err := action.Run(context.Background()); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+
+			slices.Sort(createdRefs)
+			if !slices.Equal(createdRefs, tt.want) {
+				t.Errorf("created refs = %v, want %v", createdRefs, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+
+	"github.com/google/go-github/v90/github"
+)
+
+// Cleanup modes for prerelease channel tags once a stable release ships.
+const (
+	cleanupDelete   = "delete"
+	cleanupRetarget = "retarget"
+)
+
+// channelLevels are the levels whose tags follow prereleases.
+var channelLevels = []floatingLevel{levelMajorChannel, levelMinorChannel, levelNext}
+
+// enabledChannelLevels returns the channel levels enabled in the configuration. Cleanup is limited
+// to them so that channel tags the action does not maintain are left alone.
+func (a *Action) enabledChannelLevels() []floatingLevel {
+	var levels []floatingLevel
+	for _, level := range a.levels() {
+		if slices.Contains(channelLevels, level) {
+			levels = append(levels, level)
+		}
+	}
+	return levels
+}
+
+// cleanupChannels deletes or retargets the prerelease channel tags of the version line of a
+// stable release whose newest candidate is older than that release.
+func (a *Action) cleanupChannels(ctx context.Context, owner, repo string, stable *SemVer) []error {
+	a.log.Info("Cleaning up prerelease channel tags",
+		slog.String("tag", stable.Full),
+		slog.String("mode", a.config.CleanupChannels),
+	)
+
+	// Collect the newest prerelease per channel tag of the released version line.
+	levels := a.enabledChannelLevels()
+	stale := make(map[string]*tagWithSHA)
+	_, err := a.listAllTags(ctx, owner, repo, func(tag *github.RepositoryTag) {
+		sv, err := a.parseVersion(tag.GetName())
+		if err != nil || sv.Channel == "" {
+			return
+		}
+		for _, level := range levels {
+			if !a.inVersionLine(level, sv, stable) {
+				continue
+			}
+			for _, ref := range a.floatingRefs(level, sv) {
+				if existing, ok := stale[ref]; !ok || a.greaterThan(sv, existing.semver) {
+					stale[ref] = &tagWithSHA{semver: sv, sha: tag.GetCommit().GetSHA()}
+				}
+			}
+		}
+	})
+	if err != nil {
+		return []error{fmt.Errorf("failed to collect prerelease channel tags: %w", err)}
+	}
+
+	var errs []error
+	for _, ref := range slices.Sorted(maps.Keys(stale)) {
+		candidate := stale[ref]
+		if !a.greaterThan(stable, candidate.semver) {
+			a.log.Debug("Channel tag follows a newer prerelease, keeping",
+				slog.String("tag", refDisplayName(ref)),
+				slog.String("prerelease", candidate.semver.Full),
+			)
+			continue
+		}
+
+		if reason := a.holdReason(ref, stable.Full); reason != "" {
+			a.logHeld(ref, reason)
+			continue
+		}
+
+		var err error
+		switch a.config.CleanupChannels {
+		case cleanupRetarget:
+			err = a.syncRefToSHA(ctx, owner, repo, ref, a.config.CommitSHA, stable.Full)
+		default:
+			err = a.deleteRef(ctx, owner, repo, ref)
+		}
+		if err != nil {
+			a.log.Error("Failed to clean up channel tag",
+				slog.String("tag", refDisplayName(ref)),
+				slog.String("error", err.Error()),
+			)
+			errs = append(errs, fmt.Errorf("failed to clean up channel tag %s: %w", refDisplayName(ref), err))
+		}
+	}
+	return errs
+}
+
+// inVersionLine reports whether the channel tag of prerelease sv at level belongs to the version line of stable.
+func (a *Action) inVersionLine(level floatingLevel, sv, stable *SemVer) bool {
+	switch level {
+	case levelMajorChannel:
+		return a.scheme.MajorKey(sv) == a.scheme.MajorKey(stable)
+	case levelMinorChannel:
+		return a.scheme.MinorKey(sv) == a.scheme.MinorKey(stable)
+	case levelNext:
+		return true
+	}
+	return false
+}
+
+// validateCleanupMode checks that a cleanup mode option holds a supported value.
+func validateCleanupMode(value string) error {
+	switch value {
+	case "", cleanupDelete, cleanupRetarget:
+		return nil
+	default:
+		return fmt.Errorf("invalid --cleanup-channels %q (expected %s or %s)", value, cleanupDelete, cleanupRetarget)
+	}
+}
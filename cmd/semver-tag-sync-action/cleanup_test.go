@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/google/go-github/v90/github"
+)
+
+func cleanupMock(deleted, updated *[]string) *mockGitHubClient {
+	return &mockGitHubClient{
+		listTagsFunc: func(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryTag, *github.Response, error) {
+			return []*github.RepositoryTag{
+				makeTag("v1.5.0-rc.1", "sha150rc1"),
+				makeTag("v2.0.0-rc.1", "sha200rc1"),
+				makeTag("v2.0.0-rc.2", "sha200rc2"),
+				makeTag("v2.1.0-beta.1", "sha210b1"),
+				makeTag("v2.0.0", "sha200"),
+			}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+		},
+		getRefFunc: func(ctx context.Context, owner, repo, ref string) (*github.Reference, *github.Response, error) {
+			return &github.Reference{
+				Object: &github.GitObject{SHA: github.Ptr("old")},
+			}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+		},
+		updateRefFunc: func(ctx context.Context, owner, repo, ref string, updateRef github.UpdateRef) (*github.Reference, *github.Response, error) {
+			*updated = append(*updated, ref+"="+updateRef.SHA)
+			return &github.Reference{}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+		},
+		deleteRefFunc: func(ctx context.Context, owner, repo, ref string) (*github.Response, error) {
+			*deleted = append(*deleted, ref)
+			return &github.Response{Response: &http.Response{StatusCode: http.StatusNoContent}}, nil
+		},
+	}
+}
+
+func TestActionRun_CleanupChannels(t *testing.T) {
+	tests := []struct {
+		name        string
+		mode        string
+		syncMinor   bool
+		dryRun      bool
+		wantDeleted []string
+		wantUpdated []string
+	}{
+		{
+			name:        "delete stale channel tags",
+			mode:        cleanupDelete,
+			wantDeleted: []string{"tags/v2-rc"},
+			wantUpdated: []string{"tags/v2=sha200"},
+		},
+		{
+			name:        "retarget stale channel tags",
+			mode:        cleanupRetarget,
+			wantUpdated: []string{"tags/v2=sha200", "tags/v2-rc=sha200"},
+		},
+		{
+			name:        "delete stale channel tags of enabled levels only",
+			mode:        cleanupDelete,
+			syncMinor:   true,
+			wantDeleted: []string{"tags/v2-rc", "tags/v2.0-rc"},
+			wantUpdated: []string{"tags/v2=sha200", "tags/v2.0=sha200"},
+		},
+		{
+			name:   "dry run changes nothing",
+			mode:   cleanupDelete,
+			dryRun: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deleted, updated []string
+			config := Config{
+				GitHubRepo:      "owner/repo",
+				GitRef:          "refs/tags/v2.0.0",
+				CommitSHA:       "sha200",
+				SyncMajor:       true,
+				SyncMinor:       tt.syncMinor,
+				SkipPrereleases: true,
+				SyncChannels:    true,
+				CleanupChannels: tt.mode,
+				DryRun:          tt.dryRun,
+			}
+
+			action := NewAction(cleanupMock(&deleted, &updated), config, nil)
+			if err := action.Run(context.Background()); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+
+			if !slices.Equal(deleted, tt.wantDeleted) {
+				t.Errorf("deleted refs = %v, want %v", deleted, tt.wantDeleted)
+			}
+			if !slices.Equal(updated, tt.wantUpdated) {
+				t.Errorf("updated refs = %v, want %v", updated, tt.wantUpdated)
+			}
+		})
+	}
+}
+
+func TestActionRun_CleanupChannelsSkipsPrereleases(t *testing.T) {
+	var deleted, updated []string
+	config := Config{
+		GitHubRepo:      "owner/repo",
+		GitRef:          "refs/tags/v2.0.0-rc.3",
+		CommitSHA:       "sha200rc3",
+		SyncMajor:       true,
+		SkipPrereleases: true,
+		SyncChannels:    true,
+		CleanupChannels: cleanupDelete,
+	}
+
+	action := NewAction(cleanupMock(&deleted, &updated), config, nil)
+	if err := action.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if len(deleted) != 0 {
+		t.Errorf("expected no deletions for a prerelease, got %v", deleted)
+	}
+}
@@ -0,0 +1,487 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// options holds the values of all command-line flags. Flags a command does not register keep their defaults.
+type options struct {
+	githubToken         string
+	githubRepo          string
+	gitRef              string
+	commitSHA           string
+	syncMajor           bool
+	syncMinor           bool
+	skipPrereleases     bool
+	overrides           string
+	versionScheme       string
+	calverFormat        string
+	fourPartVersions    bool
+	strictSemVer        bool
+	lenientSemVer       bool
+	canonicalPrefix     string
+	frozenTags          string
+	pins                string
+	syncPatch           bool
+	zeroMajor           string
+	buildMetadata       string
+	syncAllTags         bool
+	verify              bool
+	verifyFormat        string
+	driftIssue          bool
+	driftIssueLabel     string
+	inspect             bool
+	inspectFormat       string
+	explain             string
+	includeTags         string
+	excludeTags         string
+	versionConstraint   string
+	supportedMajors     string
+	unsupportedMajors   string
+	dryRun              bool
+	planScript          string
+	githubEnterpriseURL string
+	logLevel            string
+	syncLatest          bool
+	latestTag           string
+	syncChannels        bool
+	syncNext            bool
+	nextTag             string
+	cleanupChannels     string
+	gcPrereleases       bool
+	gcScope             string
+	gcKeepLast          int
+	gcMinAge            string
+	gcExclude           string
+	majorRefType        string
+	minorRefType        string
+	majorTagTemplate    string
+	minorTagTemplate    string
+	majorBranchTemplate string
+	minorBranchTemplate string
+	recordHistory       bool
+	historyBranch       string
+	showHistory         bool
+	historyTag          string
+	historyAt           string
+	showVersion         bool
+	configFile          string
+	configSource        string
+}
+
+// defaultOptions returns the options with every flag at its default value.
+func defaultOptions() *options {
+	return &options{
+		syncMajor:           true,
+		syncMinor:           true,
+		skipPrereleases:     true,
+		versionScheme:       schemeSemVer,
+		calverFormat:        defaultCalVerFormat,
+		canonicalPrefix:     "v",
+		syncPatch:           true,
+		zeroMajor:           zeroMajorFloat,
+		buildMetadata:       buildMetadataPreferPlain,
+		unsupportedMajors:   unsupportedKeep,
+		verifyFormat:        verifyFormatText,
+		driftIssueLabel:     defaultDriftIssueLabel,
+		inspectFormat:       inspectFormatTable,
+		logLevel:            "info",
+		latestTag:           defaultLatestTag,
+		nextTag:             defaultNextTag,
+		gcScope:             gcScopePatch,
+		majorRefType:        refTypeTag,
+		minorRefType:        refTypeTag,
+		majorTagTemplate:    defaultMajorTagTemplate,
+		minorTagTemplate:    defaultMinorTagTemplate,
+		majorBranchTemplate: defaultMajorBranchTemplate,
+		minorBranchTemplate: defaultMinorBranchTemplate,
+		historyBranch:       "semver-tag-sync/history",
+		configFile:          defaultConfigFile,
+		configSource:        configSourceAuto,
+	}
+}
+
+// flagGroup registers a group of related flags, using the current option values as defaults.
+type flagGroup func(fs *flag.FlagSet, o *options)
+
+// commonFlags are shared by all commands.
+func commonFlags(fs *flag.FlagSet, o *options) {
+	fs.StringVar(&o.githubToken, "github-token", o.githubToken, "GitHub token for authentication (or set GITHUB_TOKEN)")
+	fs.StringVar(&o.githubRepo, "github-repo", o.githubRepo, "Target repository in owner/repo format (default: GITHUB_REPOSITORY)")
+	fs.StringVar(&o.githubEnterpriseURL, "github-enterprise-url", o.githubEnterpriseURL, "GitHub Enterprise URL (optional)")
+	fs.StringVar(&o.logLevel, "log-level", o.logLevel, "Log level (debug, info, warn, error)")
+	fs.StringVar(&o.configFile, "config-file", o.configFile, "Path of the repository configuration file")
+	fs.StringVar(&o.configSource, "config-source", o.configSource, "Where to read the configuration file from (auto, local, repo, none)")
+}
+
+// versionFlags select how release tags are parsed and ordered.
+func versionFlags(fs *flag.FlagSet, o *options) {
+	fs.StringVar(&o.versionScheme, "version-scheme", o.versionScheme, "Version scheme of release tags (semver, calver, pep440)")
+	fs.StringVar(&o.calverFormat, "calver-format", o.calverFormat, "Calendar versioning format, e.g., YYYY.MM.MICRO or YY.0M (with --version-scheme=calver)")
+	fs.BoolVar(&o.fourPartVersions, "four-part-versions", o.fourPartVersions, "Release tags have four numeric components (e.g., v1.2.3.4)")
+	fs.BoolVar(&o.strictSemVer, "strict-semver", o.strictSemVer, "Only accept tags following the full SemVer 2.0 grammar")
+	fs.BoolVar(&o.lenientSemVer, "lenient-semver", o.lenientSemVer, "Accept loosely formatted tags like V1.2.3, 1.2.3 or v1.2 and normalize them")
+	fs.StringVar(&o.canonicalPrefix, "canonical-prefix", o.canonicalPrefix, "Prefix of floating tags for normalized tags with --lenient-semver")
+	fs.StringVar(&o.buildMetadata, "build-metadata", o.buildMetadata, "How to choose between versions differing only in build metadata (prefer-plain, ignore, newest, lexical)")
+}
+
+// syncFlags select which floating refs are maintained and how they are named.
+func syncFlags(fs *flag.FlagSet, o *options) {
+	fs.BoolVar(&o.syncMajor, "sync-major", o.syncMajor, "Sync major version tag (e.g., v1)")
+	fs.BoolVar(&o.syncMinor, "sync-minor", o.syncMinor, "Sync minor version tag (e.g., v1.2)")
+	fs.BoolVar(&o.skipPrereleases, "skip-prereleases", o.skipPrereleases, "Skip syncing for prerelease versions (e.g., v1.2.3-beta)")
+	fs.StringVar(&o.overrides, "overrides", o.overrides, "Rules overriding --sync-major, --sync-minor and --skip-prereleases per major or tag prefix (e.g., \"major 1: sync-major=false; prefix v2.: skip-prereleases=false\")")
+	fs.BoolVar(&o.syncPatch, "sync-patch", o.syncPatch, "Sync patch version tag (e.g., v1.2.3 for v1.2.3.4) with --four-part-versions")
+	fs.StringVar(&o.zeroMajor, "zero-major", o.zeroMajor, "Major floating tag policy while the major version is 0 (float, skip, minor)")
+	fs.BoolVar(&o.syncLatest, "sync-latest", o.syncLatest, "Sync a repository-wide tag pointing to the highest stable release")
+	fs.StringVar(&o.latestTag, "latest-tag", o.latestTag, "Name of the repository-wide latest tag")
+	fs.BoolVar(&o.syncChannels, "sync-prerelease-channels", o.syncChannels, "Sync per-channel floating tags for prereleases (e.g., v2-rc, v2.0-rc)")
+	fs.BoolVar(&o.syncNext, "sync-next", o.syncNext, "Sync a repository-wide tag pointing to the highest prerelease")
+	fs.StringVar(&o.nextTag, "next-tag", o.nextTag, "Name of the repository-wide prerelease tag")
+	fs.StringVar(&o.majorRefType, "major-ref-type", o.majorRefType, "Ref type for the major floating ref (tag, branch, both)")
+	fs.StringVar(&o.minorRefType, "minor-ref-type", o.minorRefType, "Ref type for the minor floating ref (tag, branch, both)")
+	fs.StringVar(&o.majorTagTemplate, "major-tag-template", o.majorTagTemplate, "Name template for the major floating tag ({prefix}, {major})")
+	fs.StringVar(&o.minorTagTemplate, "minor-tag-template", o.minorTagTemplate, "Name template for the minor floating tag ({prefix}, {major}, {minor})")
+	fs.StringVar(&o.majorBranchTemplate, "major-branch-template", o.majorBranchTemplate, "Name template for the major floating branch ({prefix}, {major})")
+	fs.StringVar(&o.minorBranchTemplate, "minor-branch-template", o.minorBranchTemplate, "Name template for the minor floating branch ({prefix}, {major}, {minor})")
+	fs.StringVar(&o.frozenTags, "frozen-tags", o.frozenTags, "Comma-separated names or glob patterns of floating refs that are never moved")
+	fs.StringVar(&o.pins, "pin", o.pins, "Comma-separated floating=release pins holding floating refs at a release (e.g., v1=v1.9.3)")
+}
+
+// releaseFlags identify the single release tag to sync.
+func releaseFlags(fs *flag.FlagSet, o *options) {
+	fs.StringVar(&o.gitRef, "git-ref", o.gitRef, "Git reference, e.g., refs/tags/v1.2.3 (default: GITHUB_REF)")
+	fs.StringVar(&o.commitSHA, "commit-sha", o.commitSHA, "Commit SHA to point the tags to (default: GITHUB_SHA)")
+	fs.StringVar(&o.cleanupChannels, "cleanup-channels", o.cleanupChannels, "When a stable release ships, delete or retarget stale prerelease channel tags of its version line (delete, retarget)")
+}
+
+// selectionFlags select the tags and majors considered when syncing all tags.
+func selectionFlags(fs *flag.FlagSet, o *options) {
+	fs.StringVar(&o.includeTags, "include-tags", o.includeTags, "Comma-separated glob patterns or /regexes/ of tags considered by sync-all")
+	fs.StringVar(&o.excludeTags, "exclude-tags", o.excludeTags, "Comma-separated glob patterns or /regexes/ of tags ignored by sync-all")
+	fs.StringVar(&o.versionConstraint, "version-constraint", o.versionConstraint, "Version range of tags considered by sync-all (e.g., \">=2.0.0 <4\", ^3)")
+	fs.StringVar(&o.supportedMajors, "supported-majors", o.supportedMajors, "Number of most recent majors, or comma-separated majors (e.g., v2,v3), whose floating refs sync-all maintains")
+	fs.StringVar(&o.unsupportedMajors, "unsupported-majors", o.unsupportedMajors, "What sync-all does with floating refs of unsupported majors (keep, archive, delete)")
+}
+
+// verifyFlags configure the report of floating refs out of sync.
+func verifyFlags(fs *flag.FlagSet, o *options) {
+	fs.StringVar(&o.verifyFormat, "verify-format", o.verifyFormat, "Output format of floating refs out of sync (text, json, annotations)")
+	fs.BoolVar(&o.driftIssue, "drift-issue", o.driftIssue, "Open or update an issue while floating refs are out of sync and close it once they are in sync")
+	fs.StringVar(&o.driftIssueLabel, "drift-issue-label", o.driftIssueLabel, "Label marking the drift issue")
+}
+
+// inspectFlags configure the listing of version lines.
+func inspectFlags(fs *flag.FlagSet, o *options) {
+	fs.StringVar(&o.inspectFormat, "inspect-format", o.inspectFormat, "Output format of the version lines (table, json, yaml, csv)")
+}
+
+// gcFlags configure the deletion of superseded prerelease tags.
+func gcFlags(fs *flag.FlagSet, o *options) {
+	fs.StringVar(&o.gcScope, "gc-scope", o.gcScope, "Line in which a stable release supersedes a prerelease (patch, minor, major)")
+	fs.IntVar(&o.gcKeepLast, "gc-keep-last", o.gcKeepLast, "Keep the N newest superseded prereleases of each line")
+	fs.StringVar(&o.gcMinAge, "gc-min-age", o.gcMinAge, "Only delete prereleases whose commit is older than this (e.g., 30d, 720h)")
+	fs.StringVar(&o.gcExclude, "gc-exclude", o.gcExclude, "Comma-separated glob patterns of prerelease tags never to delete")
+}
+
+// writeFlags apply to commands that change refs.
+func writeFlags(fs *flag.FlagSet, o *options) {
+	fs.BoolVar(&o.dryRun, "dry-run", o.dryRun, "Perform a dry run without making changes")
+	fs.BoolVar(&o.recordHistory, "record-history", o.recordHistory, "Append every floating tag move to a JSON log on the history branch")
+}
+
+// planFlags apply to commands that can plan their changes instead of making them.
+func planFlags(fs *flag.FlagSet, o *options) {
+	fs.StringVar(&o.planScript, "plan-script", o.planScript, "Write the changes of a dry run as a script of git commands to this path")
+}
+
+// historyBranchFlag names the branch holding the move history.
+func historyBranchFlag(fs *flag.FlagSet, o *options) {
+	fs.StringVar(&o.historyBranch, "history-branch", o.historyBranch, "Branch holding the floating tag move history")
+}
+
+// historyFlags filter the printed move history.
+func historyFlags(fs *flag.FlagSet, o *options) {
+	fs.StringVar(&o.historyTag, "history-tag", o.historyTag, "Only show history for this floating tag (e.g., v2)")
+	fs.StringVar(&o.historyAt, "history-at", o.historyAt, "Show where --history-tag pointed at this time (RFC 3339 or YYYY-MM-DD)")
+}
+
+// legacyFlags select the mode of the flag-only invocation used by the GitHub Action.
+func legacyFlags(fs *flag.FlagSet, o *options) {
+	fs.BoolVar(&o.syncAllTags, "sync-all-tags", o.syncAllTags, "Sync major/minor tags for all existing semver tags in the repository")
+	fs.BoolVar(&o.verify, "verify", o.verify, "Fail if a floating ref does not point to its expected release instead of syncing")
+	fs.BoolVar(&o.inspect, "inspect", o.inspect, "List the version lines and the targets of their floating refs instead of syncing")
+	fs.StringVar(&o.explain, "explain", o.explain, "Explain how the release of this floating ref (e.g., v1.4) is chosen instead of syncing")
+	fs.BoolVar(&o.gcPrereleases, "gc-prereleases", o.gcPrereleases, "Delete prerelease tags superseded by a stable release instead of syncing")
+	fs.BoolVar(&o.showHistory, "history", o.showHistory, "Print the recorded floating tag moves instead of syncing")
+	fs.BoolVar(&o.showVersion, "version", o.showVersion, "Show version information")
+}
+
+// command is a subcommand of the CLI.
+type command struct {
+	name    string
+	summary string
+	groups  []flagGroup
+	mode    func(o *options) // Selects the mode of the command, if any
+	arg     string           // Name of the single positional argument, if any
+	setArg  func(o *options, value string)
+}
+
+// commands lists the subcommands. The flag-only invocation without a command behaves like
+// sync unless one of --sync-all-tags, --verify, --inspect, --explain, --gc-prereleases or --history selects another mode.
+var commands = []command{
+	{
+		name:    "sync",
+		summary: "Sync the floating refs of a single release tag",
+		groups:  []flagGroup{commonFlags, versionFlags, syncFlags, releaseFlags, writeFlags, planFlags, historyBranchFlag},
+	},
+	{
+		name:    "sync-all",
+		summary: "Sync the floating refs of all release tags in the repository",
+		groups:  []flagGroup{commonFlags, versionFlags, syncFlags, selectionFlags, writeFlags, planFlags, historyBranchFlag},
+		mode:    func(o *options) { o.syncAllTags = true },
+	},
+	{
+		name:    "plan",
+		summary: "Show the changes sync-all would make without making them",
+		groups:  []flagGroup{commonFlags, versionFlags, syncFlags, selectionFlags, planFlags},
+		mode:    func(o *options) { o.syncAllTags, o.dryRun = true, true },
+	},
+	{
+		name:    "verify",
+		summary: "Fail if a floating ref does not point to its expected release",
+		groups:  []flagGroup{commonFlags, versionFlags, syncFlags, selectionFlags, verifyFlags},
+		mode:    func(o *options) { o.verify = true },
+	},
+	{
+		name:    "inspect",
+		summary: "List the version lines and the targets of their floating refs",
+		groups:  []flagGroup{commonFlags, versionFlags, syncFlags, selectionFlags, inspectFlags},
+		mode:    func(o *options) { o.inspect = true },
+	},
+	{
+		name:    "explain",
+		summary: "Explain how the release of a floating ref is chosen and what a sync would do",
+		groups:  []flagGroup{commonFlags, versionFlags, syncFlags, selectionFlags},
+		arg:     "<floating-ref>",
+		setArg:  func(o *options, value string) { o.explain = value },
+	},
+	{
+		name:    "prune",
+		summary: "Delete prerelease tags superseded by a stable release",
+		groups:  []flagGroup{commonFlags, versionFlags, gcFlags, writeFlags, planFlags, historyBranchFlag},
+		mode:    func(o *options) { o.gcPrereleases = true },
+	},
+	{
+		name:    "history",
+		summary: "Print the recorded floating tag moves",
+		groups:  []flagGroup{commonFlags, historyBranchFlag, historyFlags},
+		mode:    func(o *options) { o.showHistory = true },
+	},
+}
+
+// legacyCommand is the flag-only invocation, which accepts every flag.
+var legacyCommand = command{
+	groups: []flagGroup{commonFlags, versionFlags, syncFlags, releaseFlags, selectionFlags, verifyFlags, inspectFlags, gcFlags, writeFlags, planFlags, historyBranchFlag, historyFlags, legacyFlags},
+}
+
+// parseCommandLine selects the command named by the first argument, or the flag-only
+// invocation if there is none, and parses its flags into o.
+func parseCommandLine(args []string, o *options) (*command, *flag.FlagSet, error) {
+	cmd := &legacyCommand
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		i := slices.IndexFunc(commands, func(c command) bool { return c.name == args[0] })
+		if i < 0 {
+			return nil, nil, fmt.Errorf("unknown command %q (expected %s)", args[0], strings.Join(commandNames(), ", "))
+		}
+		cmd, args = &commands[i], args[1:]
+	}
+
+	// Accept the positional argument before the flags as well as after them.
+	var positional []string
+	if cmd.arg != "" && len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		positional, args = args[:1], args[1:]
+	}
+	fs := cmd.flagSet(o)
+	if err := fs.Parse(omitEmptyFlags(args)); err != nil {
+		return nil, nil, err
+	}
+	positional = append(positional, fs.Args()...)
+	switch {
+	case cmd.arg == "" && len(positional) > 0:
+		return nil, nil, fmt.Errorf("unexpected argument %q", positional[0])
+	case cmd.arg != "" && len(positional) != 1:
+		return nil, nil, fmt.Errorf("%s expects a single %s argument", cmd.name, cmd.arg)
+	case cmd.arg != "":
+		cmd.setArg(o, positional[0])
+	}
+	if cmd.mode != nil {
+		cmd.mode(o)
+	}
+	return cmd, fs, nil
+}
+
+// syncAllArgs returns the flags set to a value other than their default, after the configuration
+// file was applied, that the sync-all command accepts. Flags identifying the run, selecting the
+// command or making it a dry run are left out.
+func syncAllArgs(flags *flag.FlagSet) []string {
+	i := slices.IndexFunc(commands, func(c command) bool { return c.name == "sync-all" })
+	syncAll := commands[i].flagSet(defaultOptions())
+	var args []string
+	flags.VisitAll(func(f *flag.Flag) {
+		switch {
+		case f.Value.String() == f.DefValue, syncAll.Lookup(f.Name) == nil:
+			return
+		case slices.Contains(configFileDenied, f.Name), f.Name == "dry-run", f.Name == "plan-script":
+			return
+		}
+		args = append(args, "--"+f.Name+"="+f.Value.String())
+	})
+	return args
+}
+
+// omitEmptyFlags drops flags given an empty value, such as --sync-major=, which is how the
+// action passes inputs that are not set. They keep their defaults and do not count as set,
+// so the configuration file applies to them.
+func omitEmptyFlags(args []string) []string {
+	var kept []string
+	for i, arg := range args {
+		if arg == "--" {
+			return append(kept, args[i:]...)
+		}
+		if name, ok := strings.CutSuffix(arg, "="); ok && strings.HasPrefix(name, "-") && !strings.Contains(name, "=") {
+			continue
+		}
+		kept = append(kept, arg)
+	}
+	return kept
+}
+
+// flagSet returns a flag set with the flags of the command bound to o and a usage message.
+func (c *command) flagSet(o *options) *flag.FlagSet {
+	name := "semver-tag-sync-action"
+	if c.name != "" {
+		name += " " + c.name
+	}
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	for _, group := range c.groups {
+		group(fs, o)
+	}
+	fs.Usage = func() {
+		out := fs.Output()
+		if c.name == "" {
+			fmt.Fprintf(out, "Usage: %s [command] [flags]\n\nCommands:\n", name)
+			for _, cmd := range commands {
+				fmt.Fprintf(out, "  %-10s %s\n", cmd.name, cmd.summary)
+			}
+			fmt.Fprintf(out, "\nRun %s <command> -h for the flags of a command. Without a command, every flag is accepted:\n\n", name)
+		} else {
+			fmt.Fprintf(out, "Usage: %s\n\n%s.\n\nFlags:\n", strings.TrimSpace(name+" [flags] "+c.arg), c.summary)
+		}
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// commandNames returns the names of all commands.
+func commandNames() []string {
+	var names []string
+	for _, cmd := range commands {
+		names = append(names, cmd.name)
+	}
+	return names
+}
+
+// knownFlags returns a flag set with every flag, used to validate configuration file keys.
+func knownFlags() *flag.FlagSet {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	o := defaultOptions()
+	for _, group := range legacyCommand.groups {
+		group(fs, o)
+	}
+	return fs
+}
+
+// config builds the action configuration from the options.
+func (o *options) config() (Config, error) {
+	minAge, err := parseAge(o.gcMinAge)
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid --gc-min-age: %w", err)
+	}
+	pinned, err := parsePins(o.pins)
+	if err != nil {
+		return Config{}, err
+	}
+	overrides, err := parseOverrides(o.overrides)
+	if err != nil {
+		return Config{}, err
+	}
+
+	return Config{
+		GitHubToken:         o.githubToken,
+		GitHubRepo:          o.githubRepo,
+		GitRef:              o.gitRef,
+		CommitSHA:           o.commitSHA,
+		SyncMajor:           o.syncMajor,
+		SyncMinor:           o.syncMinor,
+		SkipPrereleases:     o.skipPrereleases,
+		Overrides:           overrides,
+		VersionScheme:       o.versionScheme,
+		CalVerFormat:        o.calverFormat,
+		FourPartVersions:    o.fourPartVersions,
+		StrictSemVer:        o.strictSemVer,
+		LenientSemVer:       o.lenientSemVer,
+		CanonicalPrefix:     o.canonicalPrefix,
+		FrozenTags:          splitList(o.frozenTags),
+		Pins:                pinned,
+		SyncPatch:           o.syncPatch,
+		ZeroMajor:           o.zeroMajor,
+		BuildMetadata:       o.buildMetadata,
+		SyncAllTags:         o.syncAllTags,
+		Verify:              o.verify,
+		VerifyFormat:        o.verifyFormat,
+		DriftIssue:          o.driftIssue,
+		DriftIssueLabel:     o.driftIssueLabel,
+		Inspect:             o.inspect,
+		InspectFormat:       o.inspectFormat,
+		Explain:             o.explain,
+		IncludeTags:         splitList(o.includeTags),
+		ExcludeTags:         splitList(o.excludeTags),
+		VersionConstraint:   o.versionConstraint,
+		SupportedMajors:     o.supportedMajors,
+		UnsupportedMajors:   o.unsupportedMajors,
+		DryRun:              o.dryRun,
+		PlanScript:          o.planScript,
+		GitHubEnterpriseURL: o.githubEnterpriseURL,
+		LogLevel:            o.logLevel,
+		SyncLatest:          o.syncLatest,
+		LatestTag:           o.latestTag,
+		SyncChannels:        o.syncChannels,
+		SyncNext:            o.syncNext,
+		NextTag:             o.nextTag,
+		CleanupChannels:     o.cleanupChannels,
+		GCPrereleases:       o.gcPrereleases,
+		GCScope:             o.gcScope,
+		GCKeepLast:          o.gcKeepLast,
+		GCMinAge:            minAge,
+		GCExclude:           splitList(o.gcExclude),
+		MajorRefType:        o.majorRefType,
+		MinorRefType:        o.minorRefType,
+		MajorTagTemplate:    o.majorTagTemplate,
+		MinorTagTemplate:    o.minorTagTemplate,
+		MajorBranchTemplate: o.majorBranchTemplate,
+		MinorBranchTemplate: o.minorBranchTemplate,
+		RecordHistory:       o.recordHistory,
+		HistoryBranch:       o.historyBranch,
+		ShowHistory:         o.showHistory,
+		HistoryTag:          o.historyTag,
+		HistoryAt:           o.historyAt,
+		WorkflowName:        os.Getenv("GITHUB_WORKFLOW"),
+		RunID:               os.Getenv("GITHUB_RUN_ID"),
+		RunURL:              workflowRunURL(),
+	}, nil
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseCommandLine(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		command string
+		check   func(o *options) bool
+		wantErr bool
+	}{
+		{
+			name:  "flag-only invocation",
+			args:  []string{"--sync-all-tags=true", "--include-tags=v2.*"},
+			check: func(o *options) bool { return o.syncAllTags && o.includeTags == "v2.*" },
+		},
+		{
+			name:    "sync",
+			args:    []string{"sync", "--git-ref=refs/tags/v1.2.3", "--sync-minor=false"},
+			command: "sync",
+			check:   func(o *options) bool { return o.gitRef == "refs/tags/v1.2.3" && !o.syncMinor && !o.syncAllTags },
+		},
+		{
+			name:    "sync-all",
+			args:    []string{"sync-all", "--supported-majors=2"},
+			command: "sync-all",
+			check:   func(o *options) bool { return o.syncAllTags && !o.dryRun && o.supportedMajors == "2" },
+		},
+		{
+			name:    "plan is a dry run of sync-all",
+			args:    []string{"plan"},
+			command: "plan",
+			check:   func(o *options) bool { return o.syncAllTags && o.dryRun },
+		},
+		{
+			name:    "verify",
+			args:    []string{"verify", "--verify-format=json"},
+			command: "verify",
+			check:   func(o *options) bool { return o.verify && o.verifyFormat == verifyFormatJSON && !o.syncAllTags },
+		},
+		{
+			name:    "inspect",
+			args:    []string{"inspect", "--inspect-format=csv"},
+			command: "inspect",
+			check:   func(o *options) bool { return o.inspect && o.inspectFormat == inspectFormatCSV },
+		},
+		{
+			name:    "explain before flags",
+			args:    []string{"explain", "v1.4", "--github-repo=owner/repo"},
+			command: "explain",
+			check:   func(o *options) bool { return o.explain == "v1.4" && o.githubRepo == "owner/repo" },
+		},
+		{
+			name:    "explain after flags",
+			args:    []string{"explain", "--github-repo=owner/repo", "release/v1"},
+			command: "explain",
+			check:   func(o *options) bool { return o.explain == "release/v1" },
+		},
+		{
+			name:    "explain without ref",
+			args:    []string{"explain", "--github-repo=owner/repo"},
+			wantErr: true,
+		},
+		{
+			name:    "prune",
+			args:    []string{"prune", "--gc-keep-last=2"},
+			command: "prune",
+			check:   func(o *options) bool { return o.gcPrereleases && o.gcKeepLast == 2 },
+		},
+		{
+			name:    "history",
+			args:    []string{"history", "--history-tag=v2"},
+			command: "history",
+			check:   func(o *options) bool { return o.showHistory && o.historyTag == "v2" },
+		},
+		{
+			name:  "empty action inputs keep their defaults",
+			args:  []string{"--sync-major=", "--gc-keep-last=", "--sync-minor=false"},
+			check: func(o *options) bool { return o.syncMajor && !o.syncMinor && o.gcKeepLast == 0 },
+		},
+		{
+			name:    "flag of another command",
+			args:    []string{"sync-all", "--gc-scope=minor"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown command",
+			args:    []string{"deploy"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := defaultOptions()
+			cmd, _, err := parseCommandLine(tt.args, o)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseCommandLine(%v) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if cmd.name != tt.command {
+				t.Errorf("command = %q, want %q", cmd.name, tt.command)
+			}
+			if !tt.check(o) {
+				t.Errorf("unexpected options %+v", *o)
+			}
+		})
+	}
+}
+
+func TestCommandFlagDefaults(t *testing.T) {
+	// Every command must register flags with the same defaults as the flag-only invocation,
+	// so that a configuration file applies the same way to all of them.
+	known := knownFlags()
+	for _, cmd := range commands {
+		fs := cmd.flagSet(defaultOptions())
+		fs.VisitAll(func(f *flag.Flag) {
+			if k := known.Lookup(f.Name); k == nil || k.DefValue != f.DefValue {
+				t.Errorf("%s: flag --%s differs from the flag-only invocation", cmd.name, f.Name)
+			}
+		})
+	}
+}
+
+func TestLegacyFlagsCoverActionInputs(t *testing.T) {
+	data, err := os.ReadFile("../../action.yml")
+	if err != nil {
+		t.Fatalf("failed to read action.yml: %v", err)
+	}
+	known := knownFlags()
+	for _, m := range regexp.MustCompile(`(?m)^\s+- --([a-z-]+)=`).FindAllStringSubmatch(string(data), -1) {
+		if known.Lookup(m[1]) == nil {
+			t.Errorf("action.yml passes unknown flag --%s", m[1])
+		}
+	}
+	if !strings.Contains(string(data), "--sync-all-tags=") {
+		t.Error("action.yml no longer uses the flag-only invocation")
+	}
+}
+
+func TestSyncAllArgs(t *testing.T) {
+	o := defaultOptions()
+	_, fs, err := parseCommandLine([]string{"verify", "--github-repo=owner/repo", "--sync-minor=false", "--verify-format=json", "--exclude-tags=*-hotfix*"}, o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Settings from the configuration file count as well.
+	if err := fs.Set("version-scheme", schemePEP440); err != nil {
+		t.Fatal(err)
+	}
+
+	got := syncAllArgs(fs)
+	want := []string{"--exclude-tags=*-hotfix*", "--sync-minor=false", "--version-scheme=pep440"}
+	if !slices.Equal(got, want) {
+		t.Errorf("syncAllArgs() = %q, want %q", got, want)
+	}
+}
@@ -3,6 +3,8 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
+	"time"
 )
 
 // Config holds the action configuration.
@@ -14,10 +16,61 @@ type Config struct {
 	SyncMajor           bool
 	SyncMinor           bool
 	SkipPrereleases     bool
+	Overrides           []override
+	VersionScheme       string
+	CalVerFormat        string
+	FourPartVersions    bool
+	StrictSemVer        bool
+	LenientSemVer       bool
+	FrozenTags          []string
+	Pins                map[string]string
+	CanonicalPrefix     string
+	SyncPatch           bool
+	ZeroMajor           string
+	BuildMetadata       string
 	SyncAllTags         bool
+	Verify              bool
+	VerifyFormat        string
+	DriftIssue          bool
+	DriftIssueLabel     string
+	Inspect             bool
+	InspectFormat       string
+	Explain             string
+	IncludeTags         []string
+	ExcludeTags         []string
+	VersionConstraint   string
+	SupportedMajors     string
+	UnsupportedMajors   string
 	DryRun              bool
+	PlanScript          string
 	GitHubEnterpriseURL string
 	LogLevel            string
+	SyncLatest          bool
+	LatestTag           string
+	SyncChannels        bool
+	SyncNext            bool
+	NextTag             string
+	CleanupChannels     string
+	GCPrereleases       bool
+	GCScope             string
+	GCKeepLast          int
+	GCMinAge            time.Duration
+	GCExclude           []string
+	MajorRefType        string
+	MinorRefType        string
+	MajorTagTemplate    string
+	MinorTagTemplate    string
+	MajorBranchTemplate string
+	MinorBranchTemplate string
+	RecordHistory       bool
+	HistoryBranch       string
+	ShowHistory         bool
+	HistoryTag          string
+	HistoryAt           string
+	WorkflowName        string
+	RunID               string
+	RunURL              string
+	SyncAllArgs         []string // Settings other than their defaults as sync-all flags, for the drift issue
 }
 
 // Validate checks the configuration for required values.
@@ -28,7 +81,7 @@ func (c *Config) Validate() error {
 	if c.GitHubRepo == "" {
 		return fmt.Errorf("github repo is required (set --github-repo or GITHUB_REPOSITORY)")
 	}
-	if !c.SyncAllTags {
+	if !c.SyncAllTags && !c.Verify && !c.Inspect && c.Explain == "" && !c.ShowHistory && !c.GCPrereleases {
 		if c.GitRef == "" {
 			return fmt.Errorf("git ref is required (set --git-ref or GITHUB_REF)")
 		}
@@ -36,12 +89,115 @@ func (c *Config) Validate() error {
 			return fmt.Errorf("commit sha is required (set --commit-sha or GITHUB_SHA)")
 		}
 	}
-	if !c.SyncMajor && !c.SyncMinor {
-		return fmt.Errorf("at least one of --sync-major or --sync-minor must be enabled")
+	if !c.syncMajorAnywhere() && !c.syncMinorAnywhere() && !(c.FourPartVersions && c.SyncPatch) && !c.SyncLatest && !c.SyncNext {
+		return fmt.Errorf("at least one of --sync-major, --sync-minor, --sync-patch, --sync-latest or --sync-next must be enabled")
+	}
+	scheme, err := newVersionScheme(*c)
+	if err != nil {
+		return err
+	}
+	if err := validateChannelTag(scheme, "--latest-tag", c.LatestTag); err != nil {
+		return err
+	}
+	if err := validateChannelTag(scheme, "--next-tag", c.NextTag); err != nil {
+		return err
+	}
+	if err := validateHolds(c.FrozenTags, c.Pins, scheme); err != nil {
+		return err
+	}
+	if _, err := newTagFilter(c.IncludeTags, c.ExcludeTags, c.VersionConstraint); err != nil {
+		return err
+	}
+	if err := validateUnsupportedMajors(c.SupportedMajors, c.UnsupportedMajors); err != nil {
+		return err
+	}
+	if err := validateVerifyFormat(c.VerifyFormat); err != nil {
+		return err
+	}
+	if err := validateInspectFormat(c.InspectFormat); err != nil {
+		return err
+	}
+	if c.DriftIssue && strings.TrimSpace(c.DriftIssueLabel) == "" {
+		return fmt.Errorf("--drift-issue-label must not be empty with --drift-issue")
+	}
+	if err := validateZeroMajor(c.ZeroMajor); err != nil {
+		return err
+	}
+	if err := validateBuildMetadata(c.BuildMetadata); err != nil {
+		return err
+	}
+	if err := validateCleanupMode(c.CleanupChannels); err != nil {
+		return err
+	}
+	if c.CleanupChannels != "" && !c.SyncChannels && !c.SyncNext {
+		return fmt.Errorf("--cleanup-channels requires --sync-prerelease-channels or --sync-next")
+	}
+	if err := validateGCScope(c.GCScope); err != nil {
+		return err
+	}
+	if c.GCKeepLast < 0 {
+		return fmt.Errorf("--gc-keep-last must not be negative")
+	}
+	if err := validateRefType("--major-ref-type", c.MajorRefType); err != nil {
+		return err
+	}
+	if err := validateRefType("--minor-ref-type", c.MinorRefType); err != nil {
+		return err
+	}
+	if err := validateNameTemplate(scheme, "--major-tag-template", c.MajorTagTemplate, "{major}"); err != nil {
+		return err
+	}
+	if err := validateNameTemplate(scheme, "--minor-tag-template", c.MinorTagTemplate, "{major}", "{minor}"); err != nil {
+		return err
+	}
+	if err := validateNameTemplate(scheme, "--major-branch-template", c.MajorBranchTemplate, "{major}"); err != nil {
+		return err
+	}
+	if err := validateNameTemplate(scheme, "--minor-branch-template", c.MinorBranchTemplate, "{major}", "{minor}"); err != nil {
+		return err
+	}
+	if err := validateLenientMinorTag(c, scheme); err != nil {
+		return err
+	}
+	if c.PlanScript != "" && !c.DryRun {
+		return fmt.Errorf("--plan-script requires --dry-run")
+	}
+	if (c.RecordHistory || c.ShowHistory) && c.HistoryBranch == "" {
+		return fmt.Errorf("history branch is required when recording or showing history (set --history-branch)")
+	}
+	if c.HistoryAt != "" {
+		if c.HistoryTag == "" {
+			return fmt.Errorf("--history-at requires --history-tag")
+		}
+		if _, err := parseHistoryTime(c.HistoryAt); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+// workflowRunURL builds the URL of the current workflow run from the GitHub Actions environment.
+func workflowRunURL() string {
+	server := os.Getenv("GITHUB_SERVER_URL")
+	repo := os.Getenv("GITHUB_REPOSITORY")
+	runID := os.Getenv("GITHUB_RUN_ID")
+	if server == "" || repo == "" || runID == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s/actions/runs/%s", server, repo, runID)
+}
+
+// splitList splits a comma-separated option value into its trimmed, non-empty items.
+func splitList(value string) []string {
+	var items []string
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // getEnvOrDefault returns the flag value if set, otherwise falls back to the environment variable.
 func getEnvOrDefault(flagValue, envVar string) string {
 	if flagValue != "" {
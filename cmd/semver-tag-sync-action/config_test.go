@@ -2,6 +2,8 @@ package main
 
 import (
 	"testing"
+
+	"github.com/google/go-github/v90/github"
 )
 
 func TestGetEnvOrDefault(t *testing.T) {
@@ -142,6 +144,297 @@ func TestConfigValidate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "only latest sync enabled",
+			config: Config{
+				GitHubToken: "token",
+				GitHubRepo:  "owner/repo",
+				GitRef:      "refs/tags/v1.2.3",
+				CommitSHA:   "abc123",
+				SyncLatest:  true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "latest tag looks like release",
+			config: Config{
+				GitHubToken: "token",
+				GitHubRepo:  "owner/repo",
+				GitRef:      "refs/tags/v1.2.3",
+				CommitSHA:   "abc123",
+				SyncLatest:  true,
+				LatestTag:   "v1.0.0",
+			},
+			wantErr: true,
+		},
+		{
+			name: "latest tag looks like a release of the version scheme",
+			config: Config{
+				GitHubToken:   "token",
+				GitHubRepo:    "owner/repo",
+				GitRef:        "refs/tags/1.2",
+				CommitSHA:     "abc123",
+				SyncLatest:    true,
+				LatestTag:     "1.2",
+				VersionScheme: schemePEP440,
+			},
+			wantErr: true,
+		},
+		{
+			name: "minor tag template renders a calver release",
+			config: Config{
+				GitHubToken:      "token",
+				GitHubRepo:       "owner/repo",
+				GitRef:           "refs/tags/v2026.10.1",
+				CommitSHA:        "abc123",
+				SyncMinor:        true,
+				VersionScheme:    schemeCalVer,
+				MinorTagTemplate: "{prefix}{major}.{minor}.0",
+			},
+			wantErr: true,
+		},
+		{
+			name: "cleanup channels without channel tags",
+			config: Config{
+				GitHubToken:     "token",
+				GitHubRepo:      "owner/repo",
+				GitRef:          "refs/tags/v1.2.3",
+				CommitSHA:       "abc123",
+				SyncMajor:       true,
+				CleanupChannels: cleanupDelete,
+			},
+			wantErr: true,
+		},
+		{
+			name: "lenient with the default minor tag template",
+			config: Config{
+				GitHubToken:     "token",
+				GitHubRepo:      "owner/repo",
+				GitRef:          "refs/tags/v1.2.3",
+				CommitSHA:       "abc123",
+				SyncMinor:       true,
+				LenientSemVer:   true,
+				CanonicalPrefix: "v",
+			},
+			wantErr: true,
+		},
+		{
+			name: "lenient with a minor tag template that is no version",
+			config: Config{
+				GitHubToken:      "token",
+				GitHubRepo:       "owner/repo",
+				GitRef:           "refs/tags/v1.2.3",
+				CommitSHA:        "abc123",
+				SyncMinor:        true,
+				LenientSemVer:    true,
+				CanonicalPrefix:  "v",
+				MinorTagTemplate: "{prefix}{major}.{minor}.x",
+			},
+			wantErr: false,
+		},
+		{
+			name: "lenient with minor branches",
+			config: Config{
+				GitHubToken:     "token",
+				GitHubRepo:      "owner/repo",
+				GitRef:          "refs/tags/v1.2.3",
+				CommitSHA:       "abc123",
+				SyncMinor:       true,
+				LenientSemVer:   true,
+				CanonicalPrefix: "v",
+				MinorRefType:    refTypeBranch,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid ref type",
+			config: Config{
+				GitHubToken:  "token",
+				GitHubRepo:   "owner/repo",
+				GitRef:       "refs/tags/v1.2.3",
+				CommitSHA:    "abc123",
+				SyncMajor:    true,
+				MajorRefType: "note",
+			},
+			wantErr: true,
+		},
+		{
+			name: "minor branch template without minor",
+			config: Config{
+				GitHubToken:         "token",
+				GitHubRepo:          "owner/repo",
+				GitRef:              "refs/tags/v1.2.3",
+				CommitSHA:           "abc123",
+				SyncMinor:           true,
+				MinorRefType:        "branch",
+				MinorBranchTemplate: "release/v{major}",
+			},
+			wantErr: true,
+		},
+		{
+			name: "show history without ref",
+			config: Config{
+				GitHubToken:   "token",
+				GitHubRepo:    "owner/repo",
+				SyncMajor:     true,
+				ShowHistory:   true,
+				HistoryBranch: "semver-tag-sync/history",
+			},
+			wantErr: false,
+		},
+		{
+			name: "record history without branch",
+			config: Config{
+				GitHubToken:   "token",
+				GitHubRepo:    "owner/repo",
+				GitRef:        "refs/tags/v1.2.3",
+				CommitSHA:     "abc123",
+				SyncMajor:     true,
+				RecordHistory: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "plan script without dry run",
+			config: Config{
+				GitHubToken: "token",
+				GitHubRepo:  "owner/repo",
+				SyncMajor:   true,
+				SyncAllTags: true,
+				PlanScript:  "plan.sh",
+			},
+			wantErr: true,
+		},
+		{
+			name: "history at without tag",
+			config: Config{
+				GitHubToken:   "token",
+				GitHubRepo:    "owner/repo",
+				SyncMajor:     true,
+				ShowHistory:   true,
+				HistoryBranch: "semver-tag-sync/history",
+				HistoryAt:     "2026-10-13",
+			},
+			wantErr: true,
+		},
+		{
+			name: "pep440 version scheme",
+			config: Config{
+				GitHubToken:   "token",
+				GitHubRepo:    "owner/repo",
+				GitRef:        "refs/tags/1.4.0rc1",
+				CommitSHA:     "abc123",
+				SyncMajor:     true,
+				VersionScheme: schemePEP440,
+			},
+			wantErr: false,
+		},
+		{
+			name: "four-part versions with only patch sync",
+			config: Config{
+				GitHubToken:      "token",
+				GitHubRepo:       "owner/repo",
+				GitRef:           "refs/tags/v1.2.3.4",
+				CommitSHA:        "abc123",
+				FourPartVersions: true,
+				SyncPatch:        true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "four-part versions with calver",
+			config: Config{
+				GitHubToken:      "token",
+				GitHubRepo:       "owner/repo",
+				GitRef:           "refs/tags/v2026.10.1",
+				CommitSHA:        "abc123",
+				SyncMajor:        true,
+				FourPartVersions: true,
+				VersionScheme:    schemeCalVer,
+			},
+			wantErr: true,
+		},
+		{
+			name: "pin to a non-release tag",
+			config: Config{
+				GitHubToken: "token",
+				GitHubRepo:  "owner/repo",
+				GitRef:      "refs/tags/v1.2.3",
+				CommitSHA:   "abc123",
+				SyncMajor:   true,
+				Pins:        map[string]string{"v1": "v1.2"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid frozen tag pattern",
+			config: Config{
+				GitHubToken: "token",
+				GitHubRepo:  "owner/repo",
+				GitRef:      "refs/tags/v1.2.3",
+				CommitSHA:   "abc123",
+				SyncMajor:   true,
+				FrozenTags:  []string{"v[1"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid include tag regex",
+			config: Config{
+				GitHubToken: "token",
+				GitHubRepo:  "owner/repo",
+				SyncAllTags: true,
+				SyncMajor:   true,
+				IncludeTags: []string{"/v(/"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid version constraint",
+			config: Config{
+				GitHubToken:       "token",
+				GitHubRepo:        "owner/repo",
+				SyncAllTags:       true,
+				SyncMajor:         true,
+				VersionConstraint: ">=2.0.0 <four",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid unsupported majors action",
+			config: Config{
+				GitHubToken:       "token",
+				GitHubRepo:        "owner/repo",
+				SyncAllTags:       true,
+				SyncMajor:         true,
+				SupportedMajors:   "2",
+				UnsupportedMajors: "hide",
+			},
+			wantErr: true,
+		},
+		{
+			name: "levels enabled only by overrides",
+			config: Config{
+				GitHubToken: "token",
+				GitHubRepo:  "owner/repo",
+				GitRef:      "refs/tags/v2.0.0",
+				CommitSHA:   "abc123",
+				Overrides:   []override{{selector: "major >=2", syncMajor: github.Ptr(true)}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown version scheme",
+			config: Config{
+				GitHubToken:   "token",
+				GitHubRepo:    "owner/repo",
+				GitRef:        "refs/tags/v1.2.3",
+				CommitSHA:     "abc123",
+				SyncMajor:     true,
+				VersionScheme: "npm",
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,272 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v90/github"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultConfigFile is the path of the repository configuration file.
+const defaultConfigFile = ".github/semver-tag-sync.yml"
+
+// Sources of the configuration file selectable with --config-source.
+const (
+	configSourceAuto  = "auto"  // The local file if it exists, the repository otherwise
+	configSourceLocal = "local" // A file in the working directory, e.g., a checkout
+	configSourceRepo  = "repo"  // The file at the target commit or default branch via the Contents API
+	configSourceNone  = "none"  // No configuration file
+)
+
+// configFileDenied lists the flags that cannot be set in the configuration file because
+// they identify the run rather than the policy, select the command, or are needed to load the file.
+var configFileDenied = []string{
+	"github-token", "github-repo", "git-ref", "commit-sha", "github-enterprise-url",
+	"config-file", "config-source", "log-level", "version",
+	"sync-all-tags", "verify", "inspect", "explain", "gc-prereleases", "history",
+}
+
+// configFileLists lists the keys holding comma-separated lists, which may be YAML sequences.
+var configFileLists = []string{"frozen-tags", "include-tags", "exclude-tags", "gc-exclude", "supported-majors"}
+
+// overrideSettings lists the keys of an override rule besides its selector.
+var overrideSettings = []string{"sync-major", "sync-minor", "skip-prereleases"}
+
+// loadConfigFile reads the configuration file from the given source, returning nil data and
+// no error if it does not exist. In the repository it is read at ref, or from the default branch
+// if ref is empty or lacks the file. The origin describes where the file was read from.
+func loadConfigFile(ctx context.Context, client GitHubClient, source, path, repository, ref string) (data []byte, origin string, err error) {
+	switch valueOrDefault(source, configSourceAuto) {
+	case configSourceNone:
+		return nil, "", nil
+	case configSourceLocal, configSourceAuto:
+		data, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			return data, path, nil
+		case !errors.Is(err, fs.ErrNotExist):
+			return nil, "", fmt.Errorf("failed to read config file %s: %w", path, err)
+		case source == configSourceLocal:
+			return nil, "", nil
+		}
+	case configSourceRepo:
+	default:
+		return nil, "", fmt.Errorf("invalid --config-source %q (expected %s, %s, %s or %s)", source, configSourceAuto, configSourceLocal, configSourceRepo, configSourceNone)
+	}
+
+	if repository == "" {
+		// Without a repository (or token) there is nothing to fetch; validation reports it.
+		return nil, "", nil
+	}
+	owner, repo, err := parseRepository(repository)
+	if err != nil {
+		return nil, "", err
+	}
+	origin = fmt.Sprintf("%s in %s", path, repository)
+	file, _, resp, err := client.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: ref})
+	if ref != "" {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			// Not at the target commit, e.g. a release cut before the file was added.
+			file, _, resp, err = client.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{})
+		} else {
+			origin += "@" + ref
+		}
+	}
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, "", nil
+		}
+		return nil, "", fmt.Errorf("failed to get config file %s: %w", origin, err)
+	}
+	if file == nil {
+		return nil, "", fmt.Errorf("config file %s is a directory", origin)
+	}
+	content, err := file.GetContent()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to decode config file %s: %w", origin, err)
+	}
+	return []byte(content), origin, nil
+}
+
+// applyRepositoryConfig loads the configuration file and applies it to flags,
+// returning the keys it applied and where the file was read from.
+func applyRepositoryConfig(ctx context.Context, client GitHubClient, flags *flag.FlagSet, source, path, repository, ref string) ([]string, string, error) {
+	explicit := explicitFlags(flags)
+	data, origin, err := loadConfigFile(ctx, client, source, path, repository, ref)
+	if err != nil {
+		return nil, "", err
+	}
+	if data == nil {
+		if explicit["config-file"] && source != configSourceNone {
+			return nil, "", fmt.Errorf("config file %s not found", path)
+		}
+		return nil, "", nil
+	}
+	applied, err := applyConfigFile(flags, data, origin, explicit)
+	return applied, origin, err
+}
+
+// applyConfigFile validates the configuration file against all known flags and sets every flag
+// of the command the file configures unless it is explicitly set, so flags take precedence over
+// the file. Keys of flags the command does not have are ignored. It returns the keys it applied.
+func applyConfigFile(flags *flag.FlagSet, data []byte, origin string, explicit map[string]bool) ([]string, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("config file %s: %w", origin, err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("config file %s: line %d: expected a mapping of settings", origin, root.Line)
+	}
+
+	known := knownFlags()
+	var applied []string
+	seen := make(map[string]bool)
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		keyNode, valueNode := root.Content[i], root.Content[i+1]
+		key := keyNode.Value
+		keyErr := func(format string, args ...any) error {
+			return fmt.Errorf("config file %s: key %q (line %d): %s", origin, key, keyNode.Line, fmt.Sprintf(format, args...))
+		}
+
+		f := known.Lookup(key)
+		switch {
+		case seen[key]:
+			return nil, keyErr("duplicate key")
+		case f == nil:
+			return nil, keyErr("unknown key")
+		case slices.Contains(configFileDenied, key):
+			return nil, keyErr("cannot be set in the config file")
+		}
+		seen[key] = true
+
+		value, err := configFileValue(f, valueNode)
+		if err != nil {
+			return nil, keyErr("%s", err)
+		}
+		if explicit[key] || flags.Lookup(key) == nil {
+			continue
+		}
+		if err := flags.Set(key, value); err != nil {
+			return nil, keyErr("%s", err)
+		}
+		applied = append(applied, key)
+	}
+	return applied, nil
+}
+
+// configFileValue converts the YAML value of a flag's key to the flag's string syntax.
+func configFileValue(f *flag.Flag, node *yaml.Node) (string, error) {
+	switch f.Name {
+	case "pin":
+		if node.Kind == yaml.MappingNode {
+			var pins []string
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				pins = append(pins, node.Content[i].Value+"="+node.Content[i+1].Value)
+			}
+			return strings.Join(pins, ","), nil
+		}
+	case "overrides":
+		if node.Kind == yaml.SequenceNode {
+			var rules []string
+			for i, item := range node.Content {
+				rule, err := configFileOverride(item)
+				if err != nil {
+					return "", fmt.Errorf("rule %d (line %d): %w", i+1, item.Line, err)
+				}
+				rules = append(rules, rule)
+			}
+			return strings.Join(rules, "\n"), nil
+		}
+	}
+	if node.Kind == yaml.SequenceNode && slices.Contains(configFileLists, f.Name) {
+		var items []string
+		for _, item := range node.Content {
+			if item.Kind != yaml.ScalarNode {
+				return "", fmt.Errorf("line %d: expected a list of strings", item.Line)
+			}
+			items = append(items, item.Value)
+		}
+		return strings.Join(items, ","), nil
+	}
+	if node.Kind != yaml.ScalarNode {
+		return "", fmt.Errorf("expected a single value")
+	}
+
+	if b, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && b.IsBoolFlag() && node.Tag != "!!bool" {
+		return "", fmt.Errorf("expected true or false, got %q", node.Value)
+	}
+	if g, ok := f.Value.(flag.Getter); ok {
+		if _, isInt := g.Get().(int); isInt && node.Tag != "!!int" {
+			return "", fmt.Errorf("expected an integer, got %q", node.Value)
+		}
+	}
+	return node.Value, nil
+}
+
+// configFileOverride converts an override mapping such as {major: 1, sync-major: false}
+// to the rule syntax of --overrides.
+func configFileOverride(node *yaml.Node) (string, error) {
+	if node.Kind == yaml.ScalarNode {
+		return node.Value, nil
+	}
+	if node.Kind != yaml.MappingNode {
+		return "", fmt.Errorf("expected a mapping with major or prefix and settings")
+	}
+	var selector string
+	var settings []string
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i].Value, node.Content[i+1]
+		switch {
+		case key == "major" || key == "prefix":
+			if selector != "" {
+				return "", fmt.Errorf("key %q: only one of major or prefix may be set", key)
+			}
+			selector = key + " " + value.Value
+		case slices.Contains(overrideSettings, key):
+			if value.Tag != "!!bool" {
+				return "", fmt.Errorf("key %q: expected true or false, got %q", key, value.Value)
+			}
+			settings = append(settings, key+"="+value.Value)
+		default:
+			return "", fmt.Errorf("unknown key %q (expected major, prefix, %s)", key, strings.Join(overrideSettings, ", "))
+		}
+	}
+	if selector == "" {
+		return "", fmt.Errorf("missing major or prefix")
+	}
+	return selector + ": " + strings.Join(settings, ", "), nil
+}
+
+// explicitFlags returns the names of the flags set on the command line, even to their default.
+// Action inputs that are not set are passed empty and dropped by omitEmptyFlags, so they are not.
+func explicitFlags(flags *flag.FlagSet) map[string]bool {
+	explicit := make(map[string]bool)
+	flags.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = true
+	})
+	return explicit
+}
+
+// attributeConfigError names the configuration file key behind a validation error that
+// refers to a flag whose value was set by the file.
+func attributeConfigError(err error, applied []string, origin string) error {
+	for _, key := range applied {
+		if regexp.MustCompile(`--` + regexp.QuoteMeta(key) + `([^a-z-]|$)`).MatchString(err.Error()) {
+			return fmt.Errorf("config file %s: key %q: %w", origin, key, err)
+		}
+	}
+	return err
+}
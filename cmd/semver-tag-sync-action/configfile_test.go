@@ -0,0 +1,217 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v90/github"
+)
+
+// configFlagSet returns a flag set with a representative subset of the action's flags.
+func configFlagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Bool("sync-major", true, "")
+	fs.Bool("sync-minor", true, "")
+	fs.Int("gc-keep-last", 0, "")
+	fs.String("frozen-tags", "", "")
+	fs.String("pin", "", "")
+	fs.String("overrides", "", "")
+	fs.String("zero-major", zeroMajorFloat, "")
+	fs.String("major-tag-template", defaultMajorTagTemplate, "")
+	fs.String("github-token", "", "")
+	fs.String("config-file", defaultConfigFile, "")
+	return fs
+}
+
+func TestApplyConfigFile(t *testing.T) {
+	fs := configFlagSet()
+	if err := fs.Parse([]string{"--sync-major=true", "--sync-minor=false"}); err != nil {
+		t.Fatal(err)
+	}
+
+	file := `
+sync-major: false
+sync-minor: true
+gc-keep-last: 3
+frozen-tags: [v1, "v1.*"]
+pin:
+  v2: v2.4.1
+overrides:
+  - major: 0
+    sync-major: false
+  - prefix: v2.
+    skip-prereleases: false
+zero-major: minor
+major-tag-template: "{prefix}{major}-stable"
+`
+	applied, err := applyConfigFile(fs, []byte(file), defaultConfigFile, explicitFlags(fs))
+	if err != nil {
+		t.Fatalf("applyConfigFile() error = %v", err)
+	}
+
+	want := map[string]string{
+		"sync-major":         "true",  // Explicit flag wins over the file, even at its default
+		"sync-minor":         "false", // Explicit flag wins over the file
+		"gc-keep-last":       "3",
+		"frozen-tags":        "v1,v1.*",
+		"pin":                "v2=v2.4.1",
+		"overrides":          "major 0: sync-major=false\nprefix v2.: skip-prereleases=false",
+		"zero-major":         zeroMajorMinor,
+		"major-tag-template": "{prefix}{major}-stable",
+	}
+	for name, value := range want {
+		if got := fs.Lookup(name).Value.String(); got != value {
+			t.Errorf("%s = %q, want %q", name, got, value)
+		}
+	}
+	for _, key := range applied {
+		if key == "sync-major" || key == "sync-minor" {
+			t.Errorf("applied keys = %v, want %s to be skipped", applied, key)
+		}
+	}
+	if _, err := parseOverrides(fs.Lookup("overrides").Value.String()); err != nil {
+		t.Errorf("converted overrides do not parse: %v", err)
+	}
+}
+
+func TestApplyConfigFile_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		want string
+	}{
+		{name: "unknown key", file: "sync-majr: true", want: `key "sync-majr" (line 1): unknown key`},
+		{name: "denied key", file: "github-token: secret", want: `key "github-token" (line 1): cannot be set in the config file`},
+		{name: "duplicate key", file: "sync-major: true\nsync-major: false", want: `key "sync-major" (line 2): duplicate key`},
+		{name: "string for bool", file: "sync-major: yes", want: `key "sync-major" (line 1): expected true or false, got "yes"`},
+		{name: "string for int", file: "gc-keep-last: three", want: `key "gc-keep-last" (line 1): expected an integer`},
+		{name: "mapping for string", file: "zero-major:\n  policy: skip", want: `key "zero-major" (line 1): expected a single value`},
+		{name: "list for scalar", file: "zero-major: [skip]", want: `key "zero-major" (line 1): expected a single value`},
+		{name: "override without selector", file: "overrides:\n  - sync-major: false", want: `key "overrides" (line 1): rule 1 (line 2): missing major or prefix`},
+		{name: "override with unknown key", file: "overrides:\n  - major: 1\n    sync-patch: false", want: `rule 1 (line 2): unknown key "sync-patch"`},
+		{name: "override with string setting", file: "overrides:\n  - major: 1\n    sync-major: no", want: `key "sync-major": expected true or false, got "no"`},
+		{name: "not a mapping", file: "- sync-major", want: "line 1: expected a mapping of settings"},
+		{name: "invalid yaml", file: "sync-major: [", want: "config file " + defaultConfigFile},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := configFlagSet()
+			_, err := applyConfigFile(fs, []byte(tt.file), defaultConfigFile, nil)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("applyConfigFile() error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyRepositoryConfig(t *testing.T) {
+	mock := &mockGitHubClient{
+		getContentsFunc: func(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error) {
+			if path != defaultConfigFile || opts.Ref != "abc123" {
+				t.Errorf("GetContents(%s, ref %s), want %s at abc123", path, opts.Ref, defaultConfigFile)
+			}
+			return historyContent("sync-major: false"), nil, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+		},
+	}
+
+	fs := configFlagSet()
+	applied, origin, err := applyRepositoryConfig(context.Background(), mock, fs, configSourceRepo, defaultConfigFile, "owner/repo", "abc123")
+	if err != nil {
+		t.Fatalf("applyRepositoryConfig() error = %v", err)
+	}
+	if want := defaultConfigFile + " in owner/repo@abc123"; origin != want {
+		t.Errorf("origin = %q, want %q", origin, want)
+	}
+	if len(applied) != 1 || fs.Lookup("sync-major").Value.String() != "false" {
+		t.Errorf("applied = %v, sync-major = %s, want sync-major=false", applied, fs.Lookup("sync-major").Value)
+	}
+}
+
+func TestApplyRepositoryConfig_DefaultBranch(t *testing.T) {
+	var refs []string
+	mock := &mockGitHubClient{
+		getContentsFunc: func(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error) {
+			refs = append(refs, opts.Ref)
+			if opts.Ref != "" {
+				resp := &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}
+				return nil, nil, resp, &github.ErrorResponse{Response: resp.Response}
+			}
+			return historyContent("sync-major: false"), nil, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+		},
+	}
+
+	fs := configFlagSet()
+	_, origin, err := applyRepositoryConfig(context.Background(), mock, fs, configSourceRepo, defaultConfigFile, "owner/repo", "abc123")
+	if err != nil {
+		t.Fatalf("applyRepositoryConfig() error = %v", err)
+	}
+	if want := []string{"abc123", ""}; !slices.Equal(refs, want) {
+		t.Errorf("GetContents refs = %q, want %q", refs, want)
+	}
+	if want := defaultConfigFile + " in owner/repo"; origin != want {
+		t.Errorf("origin = %q, want %q", origin, want)
+	}
+	if got := fs.Lookup("sync-major").Value.String(); got != "false" {
+		t.Errorf("sync-major = %s, want false from the default branch", got)
+	}
+}
+
+func TestApplyRepositoryConfig_Local(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "semver-tag-sync.yml")
+	if err := os.WriteFile(path, []byte("gc-keep-last: 2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	mock := &mockGitHubClient{
+		getContentsFunc: func(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error) {
+			t.Error("a local config file should not be fetched from the repository")
+			return nil, nil, nil, errors.New("unexpected")
+		},
+	}
+
+	fs := configFlagSet()
+	if _, _, err := applyRepositoryConfig(context.Background(), mock, fs, configSourceAuto, path, "owner/repo", ""); err != nil {
+		t.Fatalf("applyRepositoryConfig() error = %v", err)
+	}
+	if got := fs.Lookup("gc-keep-last").Value.String(); got != "2" {
+		t.Errorf("gc-keep-last = %s, want 2", got)
+	}
+}
+
+func TestApplyRepositoryConfig_Missing(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing.yml")
+
+	// A missing file at the default path falls back to the defaults.
+	fs := configFlagSet()
+	if _, origin, err := applyRepositoryConfig(context.Background(), &mockGitHubClient{}, fs, configSourceAuto, missing, "owner/repo", ""); err != nil || origin != "" {
+		t.Errorf("applyRepositoryConfig() = %q, %v, want no file and no error", origin, err)
+	}
+
+	// An explicitly requested file must exist.
+	fs = configFlagSet()
+	if err := fs.Parse([]string{"--config-file=" + missing}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := applyRepositoryConfig(context.Background(), &mockGitHubClient{}, fs, configSourceAuto, missing, "owner/repo", ""); err == nil {
+		t.Error("applyRepositoryConfig() succeeded, want error for missing explicit config file")
+	}
+}
+
+func TestAttributeConfigError(t *testing.T) {
+	err := attributeConfigError(errors.New(`invalid --zero-major "never" (expected float, skip or minor)`), []string{"zero-major"}, defaultConfigFile)
+	if want := `config file .github/semver-tag-sync.yml: key "zero-major": invalid --zero-major`; !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("attributeConfigError() = %v, want prefix %q", err, want)
+	}
+
+	err = attributeConfigError(errors.New(`invalid --zero-major "never"`), []string{"zero"}, defaultConfigFile)
+	if strings.Contains(err.Error(), "config file") {
+		t.Errorf("attributeConfigError() = %v, want the error unchanged", err)
+	}
+}
@@ -0,0 +1,290 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/google/go-github/v90/github"
+)
+
+// explainCandidate is a tag considered for a floating ref and why it was excluded, if it was.
+type explainCandidate struct {
+	tag    string
+	sv     *SemVer // nil if the tag is not a version
+	sha    string
+	reason string
+}
+
+// explainLine collects the candidates of a floating ref at one level.
+type explainLine struct {
+	level      floatingLevel
+	ref        string
+	key        string
+	candidates []explainCandidate
+}
+
+// filterReasons describes the reasons of tagFilter.skipReason in terms of the options.
+var filterReasons = map[string]string{
+	"not included":               "not matched by --include-tags",
+	"excluded":                   "excluded by --exclude-tags",
+	"outside version constraint": "outside --version-constraint",
+}
+
+// runExplain prints how the release of a floating ref is chosen and what a sync would do with it.
+func (a *Action) runExplain(ctx context.Context) error {
+	owner, repo, err := parseRepository(a.config.GitHubRepo)
+	if err != nil {
+		return err
+	}
+	targets := explainRefs(a.config.Explain)
+
+	groups := make(floatingGroups)
+	for _, level := range a.levels() {
+		groups[level] = make(map[string]*tagWithSHA)
+	}
+	a.pinned = make(map[string]*tagWithSHA)
+
+	var lines []*explainLine
+	var unparsed []explainCandidate
+	var tieErr error
+	_, err = a.listAllTags(ctx, owner, repo, func(tag *github.RepositoryTag) {
+		// Collect the latest releases exactly like sync-all, then attribute the tag to the line.
+		a.collectPinned(tag)
+		if err := a.processTag(ctx, owner, repo, tag, groups); err != nil && tieErr == nil {
+			tieErr = err
+		}
+
+		c := explainCandidate{tag: tag.GetName(), sha: tag.GetCommit().GetSHA()}
+		sv, err := a.parseVersion(c.tag)
+		switch {
+		case errors.Is(err, errFloatingRef):
+			return
+		case errors.Is(err, errBuildMetadataIgnored):
+			sv, _ = a.scheme.Parse(c.tag)
+			c.reason = "build metadata is ignored"
+		case err != nil:
+			c.reason = "not a version"
+			unparsed = append(unparsed, c)
+			return
+		}
+		c.sv = sv
+
+		for _, level := range a.levels() {
+			refs := a.floatingRefs(level, sv)
+			i := slices.IndexFunc(refs, func(ref string) bool { return slices.Contains(targets, ref) })
+			if i < 0 {
+				continue
+			}
+			line := findExplainLine(&lines, level, refs[i])
+			candidate := c
+			if candidate.reason == "" {
+				candidate.reason = a.exclusionReason(level, sv, candidate.sha)
+			}
+			if candidate.reason == "" && line.key == "" {
+				line.key = a.groupKey(level, sv)
+			}
+			line.candidates = append(line.candidates, candidate)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if tieErr != nil {
+		return tieErr
+	}
+	if len(lines) == 0 {
+		return fmt.Errorf("no release tag maps to the floating ref %s with the enabled levels (%s)", a.config.Explain, joinLevels(a.levels()))
+	}
+
+	unsupported := a.unsupportedLines(groups)
+	for i, line := range lines {
+		if i > 0 {
+			fmt.Fprintln(a.out)
+		}
+		for _, c := range unparsed {
+			// Only a floating tag shares its namespace with tags that are not versions.
+			if refKind(line.ref) == "tag" && namedAfter(c.tag, floatingName(line.ref)) {
+				line.candidates = append(line.candidates, c)
+			}
+		}
+		if err := a.explainLine(ctx, owner, repo, line, groups, unsupported); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// explainRefs returns the refs a floating ref name given on the command line may refer to.
+func explainRefs(name string) []string {
+	name = strings.TrimPrefix(name, "refs/")
+	if strings.HasPrefix(name, "tags/") || strings.HasPrefix(name, "heads/") {
+		return []string{name}
+	}
+	return []string{"tags/" + name, "heads/" + name}
+}
+
+// namedAfter reports whether tag is the name of a floating ref followed by a separator,
+// such as v1.4-final for v1.4.
+func namedAfter(tag, name string) bool {
+	rest, ok := strings.CutPrefix(tag, name)
+	return ok && rest != "" && strings.ContainsRune("-_.+", rune(rest[0]))
+}
+
+// findExplainLine returns the line of ref at level, adding it if it does not exist yet.
+func findExplainLine(lines *[]*explainLine, level floatingLevel, ref string) *explainLine {
+	for _, line := range *lines {
+		if line.level == level && line.ref == ref {
+			return line
+		}
+	}
+	line := &explainLine{level: level, ref: ref}
+	*lines = append(*lines, line)
+	return line
+}
+
+// exclusionReason returns why sv cannot be the release of its group at level, mirroring processTag,
+// or "" if it is eligible.
+func (a *Action) exclusionReason(level floatingLevel, sv *SemVer, sha string) string {
+	if reason := a.filter.skipReason(sv); reason != "" {
+		return filterReasons[reason]
+	}
+	if a.groupKey(level, sv) == "" {
+		switch {
+		case sv.IsPrerelease && a.settings(sv).skipPrereleases:
+			return "prerelease"
+		case sv.IsPrerelease:
+			return "prerelease, not followed by the " + string(level) + " level"
+		case level == levelMajorChannel || level == levelMinorChannel || level == levelNext:
+			return "not a prerelease"
+		}
+		return "not synced at the " + string(level) + " level"
+	}
+	if sha == "" {
+		return "no commit"
+	}
+	return ""
+}
+
+// explainLine prints the candidates, the winner, the current target and the planned action of a line.
+func (a *Action) explainLine(ctx context.Context, owner, repo string, line *explainLine, groups floatingGroups, unsupported map[string]bool) error {
+	kind := refKind(line.ref)
+	fmt.Fprintf(a.out, "%s %s (%s level)\n", kind, refDisplayName(line.ref), line.level)
+
+	winner := groups[line.level][line.key]
+	slices.SortStableFunc(line.candidates, func(x, y explainCandidate) int {
+		// Newest version first, tags that are not versions last.
+		if x.sv == nil || y.sv == nil {
+			return boolOrder(x.sv == nil, y.sv == nil)
+		}
+		return a.scheme.Compare(y.sv, x.sv)
+	})
+
+	fmt.Fprintln(a.out, "\nCandidates:")
+	w := tabwriter.NewWriter(a.out, 0, 0, 2, ' ', 0)
+	for _, c := range line.candidates {
+		var status string
+		switch {
+		case c.reason != "":
+			status = "excluded: " + c.reason
+		case c.tag == winner.semver.Full:
+			status = "selected"
+			if hold := a.holdReason(line.ref, c.tag); hold != "" {
+				status += " (held: " + hold + ")"
+			}
+		case c.tag == a.pinnedRelease(line.ref):
+			status = "pinned"
+		case a.scheme.Compare(c.sv, winner.semver) < 0:
+			status = "lower than " + winner.semver.Full
+		default:
+			status = "lost the build metadata tie-break (--build-metadata) to " + winner.semver.Full
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\n", c.tag, shortSHA(c.sha), status)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintln(a.out)
+
+	if winner == nil {
+		fmt.Fprintln(a.out, "Winner:  none, every candidate is excluded")
+	} else {
+		fmt.Fprintf(a.out, "Winner:  %s (%s)\n", winner.semver.Full, winner.sha)
+	}
+
+	current, exists, err := a.currentTarget(ctx, owner, repo, line.ref)
+	if err != nil {
+		return fmt.Errorf("failed to get %s %s: %w", kind, refDisplayName(line.ref), err)
+	}
+	if exists {
+		fmt.Fprintf(a.out, "Current: %s\n", current)
+	} else {
+		fmt.Fprintf(a.out, "Current: %s does not exist\n", kind)
+	}
+
+	fmt.Fprintf(a.out, "Action:  %s\n", a.explainAction(line, winner, current, exists, unsupported))
+	return nil
+}
+
+// explainAction describes what sync-all would do with the floating ref of a line.
+func (a *Action) explainAction(line *explainLine, winner *tagWithSHA, current string, exists bool, unsupported map[string]bool) string {
+	if winner == nil {
+		return "none, no eligible release"
+	}
+	if !line.level.repoWide() && unsupported[a.scheme.MajorKey(winner.semver)] {
+		switch action := valueOrDefault(a.config.UnsupportedMajors, unsupportedKeep); {
+		case !exists:
+			return "none, major " + a.scheme.MajorKey(winner.semver) + " is out of support"
+		case a.holdReason(line.ref, "") != "":
+			return "none, major " + a.scheme.MajorKey(winner.semver) + " is out of support but the ref is " + a.holdReason(line.ref, "")
+		case action == unsupportedArchive:
+			namespace, name, _ := strings.Cut(line.ref, "/")
+			return "archive to " + refDisplayName(namespace+"/"+archivePrefix+name) + ", major " + a.scheme.MajorKey(winner.semver) + " is out of support"
+		case action == unsupportedDelete:
+			return "delete, major " + a.scheme.MajorKey(winner.semver) + " is out of support"
+		default:
+			return "none, major " + a.scheme.MajorKey(winner.semver) + " is out of support (--unsupported-majors=keep)"
+		}
+	}
+
+	target, err := a.targetRelease(line.ref, winner)
+	if err != nil {
+		return "fail, " + err.Error()
+	}
+	prefix := ""
+	if target != winner {
+		prefix = "pinned to " + target.semver.Full + ", "
+	}
+	switch {
+	case a.holdReason(line.ref, target.semver.Full) != "":
+		return prefix + "none, the ref is " + a.holdReason(line.ref, target.semver.Full)
+	case !exists:
+		return prefix + fmt.Sprintf("create at %s (%s)", target.sha, target.semver.Full)
+	case current == target.sha:
+		return prefix + fmt.Sprintf("none, already points to %s", target.semver.Full)
+	}
+	return prefix + fmt.Sprintf("update from %s to %s (%s)", current, target.sha, target.semver.Full)
+}
+
+// boolOrder orders false before true.
+func boolOrder(x, y bool) int {
+	switch {
+	case x == y:
+		return 0
+	case x:
+		return 1
+	}
+	return -1
+}
+
+// joinLevels lists levels for messages.
+func joinLevels(levels []floatingLevel) string {
+	var names []string
+	for _, level := range levels {
+		names = append(names, string(level))
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v90/github"
+)
+
+func explainTags() []*github.RepositoryTag {
+	return []*github.RepositoryTag{
+		makeTag("v1.4.0", "sha140"),
+		makeTag("v1.4.1", "sha141"),
+		makeTag("v1.4.2-rc.1", "sha142rc1"),
+		makeTag("v1.4.5", "sha145"),
+		makeTag("v1.4-final", "shafinal"),
+		makeTag("v1.40-final", "shafinal40"),
+		makeTag("v1.5.0", "sha150"),
+		makeTag("v2.0.0", "sha200"),
+		makeTag("docs", "shadocs"),
+	}
+}
+
+func TestActionRunExplain(t *testing.T) {
+	config := Config{
+		GitHubRepo:      "owner/repo",
+		Explain:         "v1.4",
+		SyncMajor:       true,
+		SyncMinor:       true,
+		SkipPrereleases: true,
+		ExcludeTags:     []string{"v1.4.5"},
+	}
+	var out bytes.Buffer
+	action := NewAction(verifyMock(t, explainTags(), map[string]string{"tags/v1.4": "sha140"}), config, nil)
+	action.out = &out
+
+	if err := action.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	got := regexp.MustCompile(` {2,}`).ReplaceAllString(out.String(), " ")
+	want := `tag v1.4 (minor level)
+
+Candidates:
+ v1.4.5 sha145 excluded: excluded by --exclude-tags
+ v1.4.2-rc.1 sha142r excluded: prerelease
+ v1.4.1 sha141 selected
+ v1.4.0 sha140 lower than v1.4.1
+ v1.4-final shafina excluded: not a version
+
+Winner: v1.4.1 (sha141)
+Current: sha140
+Action: update from sha140 to sha141 (v1.4.1)
+`
+	if got != want {
+		t.Errorf("output =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestActionRunExplain_Action(t *testing.T) {
+	tests := []struct {
+		name   string
+		ref    string
+		refs   map[string]string
+		config Config
+		want   string
+	}{
+		{
+			name: "in sync",
+			ref:  "v1",
+			refs: map[string]string{"tags/v1": "sha150"},
+			want: "none, already points to v1.5.0",
+		},
+		{
+			name: "missing ref",
+			ref:  "tags/v2",
+			want: "create at sha200 (v2.0.0)",
+		},
+		{
+			name:   "pinned",
+			ref:    "v1",
+			refs:   map[string]string{"tags/v1": "sha150"},
+			config: Config{Pins: map[string]string{"v1": "v1.4.0"}},
+			want:   "pinned to v1.4.0, update from sha150 to sha140 (v1.4.0)",
+		},
+		{
+			name:   "frozen",
+			ref:    "v1",
+			refs:   map[string]string{"tags/v1": "sha100"},
+			config: Config{FrozenTags: []string{"v1"}},
+			want:   "none, the ref is frozen",
+		},
+		{
+			name:   "unsupported major",
+			ref:    "v1",
+			refs:   map[string]string{"tags/v1": "sha150"},
+			config: Config{SupportedMajors: "1", UnsupportedMajors: unsupportedArchive},
+			want:   "archive to archive/v1, major v1 is out of support",
+		},
+		{
+			name:   "branch",
+			ref:    "release/v2",
+			refs:   map[string]string{"heads/release/v2": "sha200"},
+			config: Config{MajorRefType: refTypeBoth},
+			want:   "none, already points to v2.0.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.config
+			config.GitHubRepo = "owner/repo"
+			config.Explain = tt.ref
+			config.SyncMajor = true
+			config.SkipPrereleases = true
+			var out bytes.Buffer
+			action := NewAction(verifyMock(t, explainTags(), tt.refs), config, nil)
+			action.out = &out
+
+			if err := action.Run(context.Background()); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if !strings.Contains(out.String(), "Action:  "+tt.want+"\n") {
+				t.Errorf("output =\n%s\nwant action %q", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestActionRunExplain_UnknownRef(t *testing.T) {
+	config := Config{
+		GitHubRepo:      "owner/repo",
+		Explain:         "v9",
+		SyncMajor:       true,
+		SkipPrereleases: true,
+	}
+	action := NewAction(verifyMock(t, explainTags(), nil), config, nil)
+	action.out = &bytes.Buffer{}
+
+	err := action.Run(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "no release tag maps to the floating ref v9") {
+		t.Errorf("Run() error = %v, want no release for v9", err)
+	}
+}
+
+func TestActionRunExplain_HeldCandidates(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		want   []string
+	}{
+		{
+			name:   "frozen",
+			config: Config{FrozenTags: []string{"v1.*"}},
+			want: []string{
+				" v1.4.1 sha141 selected (held: frozen)\n",
+				" v1.4.0 sha140 lower than v1.4.1\n",
+				"Action: none, the ref is frozen\n",
+			},
+		},
+		{
+			name:   "pinned",
+			config: Config{Pins: map[string]string{"v1.4": "v1.4.0"}},
+			want: []string{
+				" v1.4.1 sha141 selected (held: pinned to v1.4.0)\n",
+				" v1.4.0 sha140 pinned\n",
+				"Action: pinned to v1.4.0, none, already points to v1.4.0\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.config
+			config.GitHubRepo = "owner/repo"
+			config.Explain = "v1.4"
+			config.SyncMinor = true
+			config.SkipPrereleases = true
+			config.ExcludeTags = []string{"v1.4.5"}
+			var out bytes.Buffer
+			action := NewAction(verifyMock(t, explainTags(), map[string]string{"tags/v1.4": "sha140"}), config, nil)
+			action.out = &out
+
+			if err := action.Run(context.Background()); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			got := regexp.MustCompile(` {2,}`).ReplaceAllString(out.String(), " ")
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("output =\n%s\nwant it to contain %q", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestNamedAfter(t *testing.T) {
+	tests := []struct {
+		tag  string
+		name string
+		want bool
+	}{
+		{tag: "v1-legacy", name: "v1", want: true},
+		{tag: "v1.4-final", name: "v1.4", want: true},
+		{tag: "v1_old", name: "v1", want: true},
+		{tag: "v10-foo", name: "v1", want: false},
+		{tag: "v1legacy", name: "v1", want: false},
+		{tag: "v1", name: "v1", want: false},
+		{tag: "docs", name: "v1", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := namedAfter(tt.tag, tt.name); got != tt.want {
+			t.Errorf("namedAfter(%q, %q) = %v, want %v", tt.tag, tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// tagFilter selects the tags considered by sync-all through name patterns and a version constraint.
+type tagFilter struct {
+	include    []tagPattern
+	exclude    []tagPattern
+	constraint versionConstraint
+}
+
+// tagPattern matches tag names with a glob, or a regular expression if written as /regex/.
+type tagPattern struct {
+	glob string
+	re   *regexp.Regexp
+}
+
+// newTagFilter compiles the include and exclude patterns and the version constraint of the configuration.
+func newTagFilter(include, exclude []string, constraint string) (*tagFilter, error) {
+	f := &tagFilter{}
+	var err error
+	if f.include, err = compileTagPatterns("--include-tags", include); err != nil {
+		return nil, err
+	}
+	if f.exclude, err = compileTagPatterns("--exclude-tags", exclude); err != nil {
+		return nil, err
+	}
+	if f.constraint, err = parseVersionConstraint(constraint); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// compileTagPatterns compiles glob and /regex/ patterns, reporting the option of an invalid one.
+func compileTagPatterns(option string, patterns []string) ([]tagPattern, error) {
+	var compiled []tagPattern
+	for _, pattern := range patterns {
+		if expr, ok := strings.CutPrefix(pattern, "/"); ok && len(expr) > 0 && strings.HasSuffix(expr, "/") {
+			re, err := regexp.Compile(strings.TrimSuffix(expr, "/"))
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s pattern %q: %w", option, pattern, err)
+			}
+			compiled = append(compiled, tagPattern{re: re})
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid %s pattern %q: %w", option, pattern, err)
+		}
+		compiled = append(compiled, tagPattern{glob: pattern})
+	}
+	return compiled, nil
+}
+
+// match reports whether name matches the pattern.
+func (p tagPattern) match(name string) bool {
+	if p.re != nil {
+		return p.re.MatchString(name)
+	}
+	ok, _ := path.Match(p.glob, name)
+	return ok
+}
+
+// skipReason returns why sync-all must ignore sv, or "" if the filter selects it.
+func (f *tagFilter) skipReason(sv *SemVer) string {
+	if len(f.include) > 0 && !matchAny(f.include, sv.Full) {
+		return "not included"
+	}
+	if matchAny(f.exclude, sv.Full) {
+		return "excluded"
+	}
+	if !f.constraint.match(sv) {
+		return "outside version constraint"
+	}
+	return ""
+}
+
+// matchAny reports whether name matches any of the patterns.
+func matchAny(patterns []tagPattern, name string) bool {
+	for _, p := range patterns {
+		if p.match(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// versionConstraint is a semver range: a set of alternatives (||) of which one must hold,
+// each a set of version ranges that must all hold. The empty constraint matches every version.
+type versionConstraint [][]versionRange
+
+// versionRange is the half-open interval [lo, hi) of release numbers, optionally negated.
+// A nil bound is unbounded.
+type versionRange struct {
+	lo, hi *[3]uint64
+	negate bool
+}
+
+// constraintOperators lists the comparison operators, longest first so that prefixes match correctly.
+var constraintOperators = []string{">=", "<=", "!=", ">", "<", "=", "^", "~"}
+
+// parseVersionConstraint parses a range such as ">=2.0.0 <4", "^3", "~3.1" or "1.x || >=3".
+// Partial versions are filled with zeros for lower bounds and cover their whole line otherwise,
+// so "<=3" means "<4.0.0" and "3" means ">=3.0.0 <4.0.0".
+func parseVersionConstraint(value string) (versionConstraint, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	var constraint versionConstraint
+	for alternative := range strings.SplitSeq(value, "||") {
+		var ranges []versionRange
+		fields := strings.Fields(alternative)
+		for i := 0; i < len(fields); i++ {
+			token := fields[i]
+			// Allow a space between an operator and its version, e.g., ">= 2.0.0".
+			if slices.Contains(constraintOperators, token) && i+1 < len(fields) {
+				i++
+				token += fields[i]
+			}
+			r, err := parseVersionRange(token)
+			if err != nil {
+				return nil, fmt.Errorf("invalid --version-constraint %q: %w", value, err)
+			}
+			ranges = append(ranges, r)
+		}
+		if len(ranges) == 0 {
+			return nil, fmt.Errorf("invalid --version-constraint %q: empty alternative", value)
+		}
+		constraint = append(constraint, ranges)
+	}
+	return constraint, nil
+}
+
+// parseVersionRange parses a single comparator such as ">=2.0.0", "<4", "^3" or "3.x".
+func parseVersionRange(token string) (versionRange, error) {
+	op := ""
+	for _, candidate := range constraintOperators {
+		if rest, ok := strings.CutPrefix(token, candidate); ok {
+			op, token = candidate, rest
+			break
+		}
+	}
+
+	version, n, err := parsePartialVersion(token)
+	if err != nil {
+		return versionRange{}, err
+	}
+	lo := &version
+	next := func(level int) *[3]uint64 {
+		// next returns the lowest version above the line at the given level, e.g., 4.0.0 for 3.x.
+		if level < 0 {
+			return nil
+		}
+		bumped := [3]uint64{}
+		copy(bumped[:level], version[:level])
+		bumped[level] = version[level] + 1
+		return &bumped
+	}
+	// The line covered by the version as written: 3 covers 3.x.x, 3.1.4 covers only itself.
+	line := next(n - 1)
+
+	switch op {
+	case ">=":
+		return versionRange{lo: lo}, nil
+	case ">":
+		return versionRange{lo: line}, nil
+	case "<":
+		return versionRange{hi: lo}, nil
+	case "<=":
+		return versionRange{hi: line}, nil
+	case "!=":
+		return versionRange{lo: lo, hi: line, negate: true}, nil
+	case "^":
+		// Allow changes that do not modify the leftmost non-zero number.
+		switch {
+		case version[0] > 0 || n <= 1:
+			return versionRange{lo: lo, hi: next(0)}, nil
+		case version[1] > 0 || n == 2:
+			return versionRange{lo: lo, hi: next(1)}, nil
+		}
+		return versionRange{lo: lo, hi: next(2)}, nil
+	case "~":
+		// Allow patch-level changes if a minor version is given, minor-level changes otherwise.
+		if n <= 1 {
+			return versionRange{lo: lo, hi: next(0)}, nil
+		}
+		return versionRange{lo: lo, hi: next(1)}, nil
+	}
+	if n == 0 {
+		return versionRange{}, nil
+	}
+	return versionRange{lo: lo, hi: line}, nil
+}
+
+// parsePartialVersion parses a version like 3, 3.1, v3.1.4 or 3.x, returning it padded with
+// zeros and the number of components given. A wildcard (x, X or *) ends the version.
+func parsePartialVersion(token string) ([3]uint64, int, error) {
+	var version [3]uint64
+	token = strings.TrimPrefix(token, "v")
+	if token == "" {
+		return version, 0, fmt.Errorf("missing version")
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) > 3 {
+		return version, 0, fmt.Errorf("version %q has more than three components", token)
+	}
+	n := 0
+	for _, part := range parts {
+		if part == "x" || part == "X" || part == "*" {
+			break
+		}
+		number, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return version, 0, fmt.Errorf("version %q has an invalid component %q", token, part)
+		}
+		version[n] = number
+		n++
+	}
+	return version, n, nil
+}
+
+// match reports whether the release numbers of sv satisfy the constraint.
+func (c versionConstraint) match(sv *SemVer) bool {
+	if len(c) == 0 {
+		return true
+	}
+	for _, ranges := range c {
+		ok := true
+		for _, r := range ranges {
+			if !r.match(sv) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// match reports whether the release numbers of sv lie within the range.
+func (r versionRange) match(sv *SemVer) bool {
+	in := (r.lo == nil || compareRelease(sv, r.lo) >= 0) && (r.hi == nil || compareRelease(sv, r.hi) < 0)
+	return in != r.negate
+}
+
+// compareRelease compares the major, minor and patch numbers of sv with a bound.
+func compareRelease(sv *SemVer, bound *[3]uint64) int {
+	for i, number := range []string{sv.Major, sv.Minor, sv.Patch} {
+		if c := compareNumeric(number, strconv.FormatUint(bound[i], 10)); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
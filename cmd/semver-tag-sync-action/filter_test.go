@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/google/go-github/v90/github"
+)
+
+func TestVersionConstraint(t *testing.T) {
+	tests := []struct {
+		constraint string
+		matches    []string
+		rejects    []string
+	}{
+		{constraint: "", matches: []string{"v0.1.0", "v9.9.9"}},
+		{constraint: ">=2.0.0 <4", matches: []string{"v2.0.0", "v3.9.9"}, rejects: []string{"v1.9.9", "v4.0.0", "v4.0.0-rc.1"}},
+		{constraint: ">= 2.0.0 < 4", matches: []string{"v2.0.0"}, rejects: []string{"v4.0.0"}},
+		{constraint: "^3", matches: []string{"v3.0.0", "v3.9.1"}, rejects: []string{"v2.9.9", "v4.0.0"}},
+		{constraint: "^0.4", matches: []string{"v0.4.0", "v0.4.7"}, rejects: []string{"v0.5.0"}},
+		{constraint: "^0.0.3", matches: []string{"v0.0.3"}, rejects: []string{"v0.0.4"}},
+		{constraint: "~3.1", matches: []string{"v3.1.0", "v3.1.9"}, rejects: []string{"v3.2.0"}},
+		{constraint: "3.x", matches: []string{"v3.0.0", "v3.5.0"}, rejects: []string{"v4.0.0"}},
+		{constraint: "<=3", matches: []string{"v3.9.9"}, rejects: []string{"v4.0.0"}},
+		{constraint: ">3.1", matches: []string{"v3.2.0"}, rejects: []string{"v3.1.9"}},
+		{constraint: "=1.2.3", matches: []string{"v1.2.3"}, rejects: []string{"v1.2.4"}},
+		{constraint: "!=2", matches: []string{"v1.0.0", "v3.0.0"}, rejects: []string{"v2.1.0"}},
+		{constraint: "1.x || >=3", matches: []string{"v1.4.0", "v3.0.0"}, rejects: []string{"v2.0.0"}},
+		{constraint: ">=1", matches: []string{"v99999999999999999999.0.0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			c, err := parseVersionConstraint(tt.constraint)
+			if err != nil {
+				t.Fatalf("parseVersionConstraint(%q) error = %v", tt.constraint, err)
+			}
+			for _, tag := range tt.matches {
+				if sv, _ := ParseSemVer(tag); !c.match(sv) {
+					t.Errorf("%q does not match %s", tt.constraint, tag)
+				}
+			}
+			for _, tag := range tt.rejects {
+				if sv, _ := ParseSemVer(tag); c.match(sv) {
+					t.Errorf("%q matches %s", tt.constraint, tag)
+				}
+			}
+		})
+	}
+}
+
+func TestParseVersionConstraint_Invalid(t *testing.T) {
+	for _, constraint := range []string{"<four", ">=1.2.3.4", "||", "^", ">=1 ||"} {
+		if _, err := parseVersionConstraint(constraint); err == nil {
+			t.Errorf("parseVersionConstraint(%q) succeeded, want error", constraint)
+		}
+	}
+}
+
+func TestTagFilter_SkipReason(t *testing.T) {
+	filter, err := newTagFilter([]string{"v2.*", "/^v3\\.[01]\\./"}, []string{"*-hotfix*"}, "")
+	if err != nil {
+		t.Fatalf("newTagFilter() error = %v", err)
+	}
+
+	tests := map[string]string{
+		"v2.1.0":          "",
+		"v3.0.4":          "",
+		"v3.2.0":          "not included",
+		"v1.0.0":          "not included",
+		"v2.1.0-hotfix.1": "excluded",
+	}
+	for tag, want := range tests {
+		sv, _ := ParseSemVer(tag)
+		if got := filter.skipReason(sv); got != want {
+			t.Errorf("skipReason(%s) = %q, want %q", tag, got, want)
+		}
+	}
+}
+
+func TestActionRunAll_Filtered(t *testing.T) {
+	var createdRefs []string
+	mock := &mockGitHubClient{
+		listTagsFunc: func(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryTag, *github.Response, error) {
+			return []*github.RepositoryTag{
+				makeTag("v1.9.0", "sha190"),
+				makeTag("v2.0.0", "sha200"),
+				makeTag("v2.1.0", "sha210"),
+				makeTag("v3.0.0", "sha300"),
+				makeTag("v3.1.0-hotfix", "sha31h"),
+				makeTag("v4.0.0", "sha400"),
+			}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+		},
+		createRefFunc: func(ctx context.Context, owner, repo string, ref github.CreateRef) (*github.Reference, *github.Response, error) {
+			createdRefs = append(createdRefs, ref.Ref+"="+ref.SHA)
+			return &github.Reference{}, &github.Response{Response: &http.Response{StatusCode: http.StatusCreated}}, nil
+		},
+	}
+
+	config := Config{
+		GitHubRepo:        "owner/repo",
+		SyncAllTags:       true,
+		SyncMajor:         true,
+		ExcludeTags:       []string{"v2.1.*", "*-hotfix"},
+		VersionConstraint: ">=2.0.0 <4",
+	}
+
+	action := NewAction(mock, config, nil)
+	if err := action.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	slices.Sort(createdRefs)
+	if want := []string{"refs/tags/v2=sha200", "refs/tags/v3=sha300"}; !slices.Equal(createdRefs, want) {
+		t.Errorf("created refs = %v, want %v", createdRefs, want)
+	}
+}
+
+func TestActionRun_InvalidFilterOrScheme(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+	}{
+		{"invalid include tags", Config{IncludeTags: []string{"/v(/"}}},
+		{"invalid version constraint", Config{VersionConstraint: ">=2.0.0 <four"}},
+		{"unknown version scheme", Config{VersionScheme: "npm"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := verifyMock(t, []*github.RepositoryTag{makeTag("v1.0.0", "sha100")}, nil)
+			tt.config.GitHubRepo = "owner/repo"
+			tt.config.SyncAllTags = true
+			tt.config.SyncMajor = true
+
+			if err := NewAction(mock, tt.config, nil).Run(context.Background()); err == nil {
+				t.Error("Run() error = nil, want the invalid configuration reported")
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/go-github/v90/github"
+)
+
+// releaseIndex holds the GitHub releases and the commits of the release tags of a repository.
+type releaseIndex struct {
+	released map[string]bool // Tag names of GitHub releases
+	commits  map[string]bool // Commit SHAs of release tags
+}
+
+// patchRefConflict returns why the patch-level floating tag ref must not be moved, or "" if it may.
+// With four-part versions the patch-level tag of v1.2.3.4 is named like the three-part release
+// v1.2.3, so a tag that is a GitHub release or does not point to a four-part release is taken for
+// a release of its own and left alone.
+func (a *Action) patchRefConflict(ctx context.Context, owner, repo, ref string) (string, error) {
+	if a.releases == nil {
+		index, err := a.loadReleaseIndex(ctx, owner, repo)
+		if err != nil {
+			return "", err
+		}
+		a.releases = index
+	}
+
+	name := refDisplayName(ref)
+	if a.releases.released[name] {
+		return "a GitHub release uses the tag", nil
+	}
+	current, exists, err := a.currentTarget(ctx, owner, repo, ref)
+	if err != nil {
+		return "", fmt.Errorf("failed to check if tag %s exists: %w", name, err)
+	}
+	if exists && !a.releases.commits[current] {
+		return fmt.Sprintf("the tag points to %s, which is no four-part release", current), nil
+	}
+	return "", nil
+}
+
+// loadReleaseIndex lists the GitHub releases and the release tags of the repository.
+func (a *Action) loadReleaseIndex(ctx context.Context, owner, repo string) (*releaseIndex, error) {
+	released, err := a.releaseTags(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	index := &releaseIndex{released: released, commits: make(map[string]bool)}
+	_, err = a.listAllTags(ctx, owner, repo, func(tag *github.RepositoryTag) {
+		if _, err := a.parseVersion(tag.GetName()); err == nil {
+			index.commits[tag.GetCommit().GetSHA()] = true
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+// checkPatchLevel refuses to move a patch-level floating tag that looks like a release. Refs of
+// other levels and held refs, which are not moved anyway, are not checked.
+func (a *Action) checkPatchLevel(ctx context.Context, owner, repo string, level floatingLevel, ref, release string) error {
+	if level != levelPatch || a.holdReason(ref, release) != "" {
+		return nil
+	}
+	reason, err := a.patchRefConflict(ctx, owner, repo, ref)
+	if err != nil || reason == "" {
+		return err
+	}
+	a.log.Warn("Refusing to move patch tag, it looks like a release",
+		slog.String("tag", refDisplayName(ref)),
+		slog.String("reason", reason),
+	)
+	return fmt.Errorf("refusing to move patch tag %s: %s", refDisplayName(ref), reason)
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v90/github"
+)
+
+// mixedHistoryMock serves a repository that switched from three-part to four-part versions.
+// v1.2.3 is a GitHub release, v1.2.4 a plain three-part release tag and v1.2.5 the patch-level
+// floating tag of v1.2.5.0.
+func mixedHistoryMock(written *[]string) *mockGitHubClient {
+	refs := map[string]string{
+		"tags/v1.2.3": "sha123",
+		"tags/v1.2.4": "sha124",
+		"tags/v1.2.5": "sha1250",
+	}
+	return &mockGitHubClient{
+		listTagsFunc: func(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryTag, *github.Response, error) {
+			return []*github.RepositoryTag{
+				makeTag("v1.2.3", "sha123"),
+				makeTag("v1.2.3.1", "sha1231"),
+				makeTag("v1.2.4", "sha124"),
+				makeTag("v1.2.4.1", "sha1241"),
+				makeTag("v1.2.5", "sha1250"),
+				makeTag("v1.2.5.0", "sha1250"),
+				makeTag("v1.2.5.1", "sha1251"),
+			}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+		},
+		listReleasesFunc: func(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryRelease, *github.Response, error) {
+			return []*github.RepositoryRelease{{TagName: "v1.2.3"}}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+		},
+		getRefFunc: func(ctx context.Context, owner, repo, ref string) (*github.Reference, *github.Response, error) {
+			sha, ok := refs[ref]
+			if !ok {
+				resp := &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}
+				return nil, resp, &github.ErrorResponse{Response: resp.Response}
+			}
+			return &github.Reference{Object: &github.GitObject{SHA: github.Ptr(sha)}}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+		},
+		createRefFunc: func(ctx context.Context, owner, repo string, ref github.CreateRef) (*github.Reference, *github.Response, error) {
+			*written = append(*written, strings.TrimPrefix(ref.Ref, "refs/")+"="+ref.SHA)
+			return &github.Reference{}, &github.Response{Response: &http.Response{StatusCode: http.StatusCreated}}, nil
+		},
+		updateRefFunc: func(ctx context.Context, owner, repo, ref string, updateRef github.UpdateRef) (*github.Reference, *github.Response, error) {
+			*written = append(*written, ref+"="+updateRef.SHA)
+			return &github.Reference{}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+		},
+	}
+}
+
+func TestActionRunAll_FourPartVersionsMixedHistory(t *testing.T) {
+	var written []string
+	config := Config{
+		GitHubRepo:       "owner/repo",
+		SyncAllTags:      true,
+		FourPartVersions: true,
+		SyncPatch:        true,
+		SkipPrereleases:  true,
+	}
+
+	err := NewAction(mixedHistoryMock(&written), config, nil).Run(context.Background())
+	for _, want := range []string{
+		"refusing to move patch tag v1.2.3: a GitHub release uses the tag",
+		"refusing to move patch tag v1.2.4: the tag points to sha124, which is no four-part release",
+	} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Run() error = %v, want it to contain %q", err, want)
+		}
+	}
+
+	// Only the floating patch tag moves; the three-part releases stay where they are.
+	if want := []string{"tags/v1.2.5=sha1251"}; !slices.Equal(written, want) {
+		t.Errorf("written refs = %v, want %v", written, want)
+	}
+}
+
+func TestActionRun_FourPartVersionsRefusesRelease(t *testing.T) {
+	var written []string
+	config := Config{
+		GitHubRepo:       "owner/repo",
+		GitRef:           "refs/tags/v1.2.3.1",
+		CommitSHA:        "sha1231",
+		SyncMajor:        true,
+		FourPartVersions: true,
+		SyncPatch:        true,
+		SkipPrereleases:  true,
+	}
+
+	err := NewAction(mixedHistoryMock(&written), config, nil).Run(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "refusing to move patch tag v1.2.3") {
+		t.Errorf("Run() error = %v, want the patch tag refused", err)
+	}
+	if want := []string{"tags/v1=sha1231"}; !slices.Equal(written, want) {
+		t.Errorf("written refs = %v, want %v", written, want)
+	}
+}
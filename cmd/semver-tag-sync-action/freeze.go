@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v90/github"
+)
+
+// holdReason returns why a floating ref must not be moved to release, or "" if it may be.
+// Frozen refs never move; pinned refs only move to their pinned release.
+func (a *Action) holdReason(ref, release string) string {
+	name := floatingName(ref)
+	for _, pattern := range a.config.FrozenTags {
+		if ok, _ := path.Match(pattern, name); ok {
+			return "frozen"
+		}
+	}
+	if pin, ok := a.config.Pins[name]; ok && pin != release {
+		return "pinned to " + pin
+	}
+	return ""
+}
+
+// logHeld reports a floating ref that is left untouched because it is frozen or pinned.
+func (a *Action) logHeld(ref, reason string) {
+	kind := refKind(ref)
+	a.log.Info(capitalize(kind)+" is "+reason+", skipping",
+		slog.String(kind, refDisplayName(ref)),
+		slog.String("status", strings.Fields(reason)[0]),
+	)
+}
+
+// pinnedRelease returns the release a floating ref is pinned to, or "" if it is not pinned.
+func (a *Action) pinnedRelease(ref string) string {
+	return a.config.Pins[floatingName(ref)]
+}
+
+// targetRelease returns the release a floating ref should point to: its pinned release
+// if it is pinned, entry otherwise.
+func (a *Action) targetRelease(ref string, entry *tagWithSHA) (*tagWithSHA, error) {
+	pin := a.pinnedRelease(ref)
+	if pin == "" {
+		return entry, nil
+	}
+	// Hold the ref at its pinned release even if newer ones exist.
+	if a.pinned[pin] == nil {
+		return nil, fmt.Errorf("pinned release %s does not exist", pin)
+	}
+	return a.pinned[pin], nil
+}
+
+// collectPinned remembers tag if a floating ref is pinned to it.
+func (a *Action) collectPinned(tag *github.RepositoryTag) {
+	name := tag.GetName()
+	if !slices.Contains(slices.Collect(maps.Values(a.config.Pins)), name) {
+		return
+	}
+	if sv, err := a.parseVersion(name); err == nil {
+		a.pinned[name] = &tagWithSHA{semver: sv, sha: tag.GetCommit().GetSHA()}
+	}
+}
+
+// floatingName returns the name of a short ref without its tags/ or heads/ namespace.
+func floatingName(ref string) string {
+	if name, ok := strings.CutPrefix(ref, "heads/"); ok {
+		return name
+	}
+	return strings.TrimPrefix(ref, "tags/")
+}
+
+// parsePins parses a comma-separated list of floating=release pins such as "v1=v1.9.3,v2.4=v2.4.1".
+func parsePins(value string) (map[string]string, error) {
+	pins := make(map[string]string)
+	for _, item := range splitList(value) {
+		name, release, ok := strings.Cut(item, "=")
+		name, release = strings.TrimSpace(name), strings.TrimSpace(release)
+		if !ok || name == "" || release == "" {
+			return nil, fmt.Errorf("invalid --pin %q (expected floating=release, e.g., v1=v1.9.3)", item)
+		}
+		if _, dup := pins[name]; dup {
+			return nil, fmt.Errorf("invalid --pin %q (%s is pinned more than once)", item, name)
+		}
+		pins[name] = release
+	}
+	return pins, nil
+}
+
+// validateHolds checks the frozen tag patterns and that every pin targets a release of the scheme.
+func validateHolds(patterns []string, pins map[string]string, scheme VersionScheme) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid --frozen-tags pattern %q: %w", pattern, err)
+		}
+	}
+	for name, release := range pins {
+		if _, err := scheme.Parse(release); err != nil {
+			return fmt.Errorf("invalid --pin %s=%s: %w", name, release, err)
+		}
+	}
+	return nil
+}
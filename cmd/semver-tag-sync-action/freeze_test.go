@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v90/github"
+)
+
+func TestActionRun_FrozenTag(t *testing.T) {
+	var createdRefs []string
+	mock := &mockGitHubClient{
+		createRefFunc: func(ctx context.Context, owner, repo string, ref github.CreateRef) (*github.Reference, *github.Response, error) {
+			createdRefs = append(createdRefs, ref.Ref)
+			return &github.Reference{}, &github.Response{Response: &http.Response{StatusCode: http.StatusCreated}}, nil
+		},
+	}
+
+	config := Config{
+		GitHubRepo: "owner/repo",
+		GitRef:     "refs/tags/v1.2.3",
+		CommitSHA:  "abc123",
+		SyncMajor:  true,
+		SyncMinor:  true,
+		FrozenTags: []string{"v1"},
+	}
+
+	var logs bytes.Buffer
+	action := NewAction(mock, config, slog.New(slog.NewTextHandler(&logs, nil)))
+	if err := action.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if want := []string{"refs/tags/v1.2"}; !slices.Equal(createdRefs, want) {
+		t.Errorf("created refs = %v, want %v", createdRefs, want)
+	}
+	if !strings.Contains(logs.String(), "tag=v1 status=frozen") {
+		t.Errorf("expected v1 to be reported as frozen, got logs: %s", logs.String())
+	}
+}
+
+func TestActionRun_PinnedTag(t *testing.T) {
+	tests := []struct {
+		name string
+		ref  string
+		want []string
+	}{
+		{name: "newer release does not move pinned tag", ref: "refs/tags/v1.3.0", want: []string{"refs/tags/v1.3"}},
+		{name: "pinned release moves pinned tag", ref: "refs/tags/v1.2.3", want: []string{"refs/tags/v1", "refs/tags/v1.2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var createdRefs []string
+			mock := &mockGitHubClient{
+				createRefFunc: func(ctx context.Context, owner, repo string, ref github.CreateRef) (*github.Reference, *github.Response, error) {
+					createdRefs = append(createdRefs, ref.Ref)
+					return &github.Reference{}, &github.Response{Response: &http.Response{StatusCode: http.StatusCreated}}, nil
+				},
+			}
+
+			config := Config{
+				GitHubRepo: "owner/repo",
+				GitRef:     tt.ref,
+				CommitSHA:  "abc123",
+				SyncMajor:  true,
+				SyncMinor:  true,
+				Pins:       map[string]string{"v1": "v1.2.3"},
+			}
+
+			action := NewAction(mock, config, nil)
+			if err := action.Run(context.Background()); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+
+			if !slices.Equal(createdRefs, tt.want) {
+				t.Errorf("created refs = %v, want %v", createdRefs, tt.want)
+			}
+		})
+	}
+}
+
+func TestActionRunAll_FrozenAndPinnedTags(t *testing.T) {
+	var createdRefs []string
+	mock := &mockGitHubClient{
+		listTagsFunc: func(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryTag, *github.Response, error) {
+			return []*github.RepositoryTag{
+				makeTag("v1.1.0", "sha110"),
+				makeTag("v1.2.0", "sha120"),
+				makeTag("v2.0.0", "sha200"),
+				makeTag("v3.0.0", "sha300"),
+			}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+		},
+		createRefFunc: func(ctx context.Context, owner, repo string, ref github.CreateRef) (*github.Reference, *github.Response, error) {
+			createdRefs = append(createdRefs, ref.Ref+"="+ref.SHA)
+			return &github.Reference{}, &github.Response{Response: &http.Response{StatusCode: http.StatusCreated}}, nil
+		},
+	}
+
+	config := Config{
+		GitHubRepo:  "owner/repo",
+		SyncAllTags: true,
+		SyncMajor:   true,
+		FrozenTags:  []string{"v2*"},
+		Pins:        map[string]string{"v1": "v1.1.0", "v3": "v3.1.0"},
+	}
+
+	action := NewAction(mock, config, nil)
+	err := action.Run(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "pinned release v3.1.0 does not exist") {
+		t.Errorf("Run() error = %v, want missing pinned release", err)
+	}
+
+	if want := []string{"refs/tags/v1=sha110"}; !slices.Equal(createdRefs, want) {
+		t.Errorf("created refs = %v, want %v", createdRefs, want)
+	}
+}
+
+func TestParsePins(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    map[string]string
+		wantErr bool
+	}{
+		{value: "", want: map[string]string{}},
+		{value: "v1=v1.9.3, v2.4=v2.4.1", want: map[string]string{"v1": "v1.9.3", "v2.4": "v2.4.1"}},
+		{value: "v1", wantErr: true},
+		{value: "v1=", wantErr: true},
+		{value: "v1=v1.0.0,v1=v1.1.0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parsePins(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePins(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parsePins(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
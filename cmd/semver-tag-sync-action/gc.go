@@ -0,0 +1,276 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v90/github"
+)
+
+// Scopes defining which stable release supersedes a prerelease.
+const (
+	gcScopePatch = "patch"
+	gcScopeMinor = "minor"
+	gcScopeMajor = "major"
+)
+
+// gcDecision is the planned outcome for a superseded prerelease tag.
+type gcDecision struct {
+	tag          *tagWithSHA
+	supersededBy *SemVer
+	keepReason   string
+}
+
+// runGC deletes prerelease tags superseded by a stable release of the same line.
+func (a *Action) runGC(ctx context.Context) error {
+	a.log.Info("Starting prerelease tag garbage collection",
+		slog.String("repo", a.config.GitHubRepo),
+		slog.String("scope", a.gcScope()),
+		slog.Int("keep_last", a.config.GCKeepLast),
+		slog.Duration("min_age", a.config.GCMinAge),
+		slog.Bool("dry_run", a.config.DryRun),
+	)
+
+	owner, repo, err := parseRepository(a.config.GitHubRepo)
+	if err != nil {
+		return err
+	}
+
+	var stable, prereleases []*tagWithSHA
+	_, err = a.listAllTags(ctx, owner, repo, func(tag *github.RepositoryTag) {
+		sv, err := a.parseVersion(tag.GetName())
+		if err != nil {
+			return
+		}
+		entry := &tagWithSHA{semver: sv, sha: tag.GetCommit().GetSHA()}
+		if sv.IsPrerelease {
+			prereleases = append(prereleases, entry)
+		} else {
+			stable = append(stable, entry)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	released, err := a.releaseTags(ctx, owner, repo)
+	if err != nil {
+		return err
+	}
+
+	plan, err := a.planGC(ctx, owner, repo, stable, prereleases, released)
+	if err != nil {
+		return err
+	}
+
+	var gcErrors []error
+	deleted := 0
+	for _, decision := range plan {
+		name := decision.tag.semver.Full
+		if decision.keepReason != "" {
+			fmt.Fprintf(a.out, "keep\t%s\t%s\n", name, decision.keepReason)
+			continue
+		}
+		fmt.Fprintf(a.out, "delete\t%s\tsuperseded by %s\n", name, decision.supersededBy.Full)
+		if err := a.deleteRef(ctx, owner, repo, "tags/"+name); err != nil {
+			a.log.Error("Failed to delete prerelease tag",
+				slog.String("tag", name),
+				slog.String("error", err.Error()),
+			)
+			gcErrors = append(gcErrors, err)
+			continue
+		}
+		deleted++
+	}
+
+	if err := a.writeHistory(ctx, owner, repo); err != nil {
+		gcErrors = append(gcErrors, err)
+	}
+	if err := a.writePlanScript(); err != nil {
+		gcErrors = append(gcErrors, err)
+	}
+
+	if len(gcErrors) > 0 {
+		return errors.Join(gcErrors...)
+	}
+
+	a.log.Info("Prerelease tag garbage collection completed successfully",
+		slog.Int("superseded", len(plan)),
+		slog.Int("deleted", deleted),
+	)
+	return nil
+}
+
+// planGC decides for every superseded prerelease whether it is deleted or kept and why.
+func (a *Action) planGC(ctx context.Context, owner, repo string, stable, prereleases []*tagWithSHA, released map[string]bool) ([]gcDecision, error) {
+	// Group superseded prereleases per line, newest first.
+	lines := make(map[string][]gcDecision)
+	var lineOrder []string
+	for _, pre := range prereleases {
+		var supersededBy *SemVer
+		for _, rel := range stable {
+			if a.gcLine(rel.semver) == a.gcLine(pre.semver) && a.greaterThan(rel.semver, pre.semver) &&
+				(supersededBy == nil || a.greaterThan(rel.semver, supersededBy)) {
+				supersededBy = rel.semver
+			}
+		}
+		if supersededBy == nil {
+			continue
+		}
+		line := a.gcLine(pre.semver)
+		if _, ok := lines[line]; !ok {
+			lineOrder = append(lineOrder, line)
+		}
+		lines[line] = append(lines[line], gcDecision{tag: pre, supersededBy: supersededBy})
+	}
+	slices.Sort(lineOrder)
+
+	var plan []gcDecision
+	for _, line := range lineOrder {
+		decisions := lines[line]
+		slices.SortFunc(decisions, func(x, y gcDecision) int {
+			if a.greaterThan(x.tag.semver, y.tag.semver) {
+				return -1
+			}
+			if a.greaterThan(y.tag.semver, x.tag.semver) {
+				return 1
+			}
+			return strings.Compare(x.tag.semver.Full, y.tag.semver.Full)
+		})
+		for i := range decisions {
+			reason, err := a.gcKeepReason(ctx, owner, repo, decisions[i].tag, i, released)
+			if err != nil {
+				return nil, err
+			}
+			decisions[i].keepReason = reason
+		}
+		plan = append(plan, decisions...)
+	}
+	return plan, nil
+}
+
+// gcKeepReason returns why a superseded prerelease at the given position of its line must be kept, or "".
+func (a *Action) gcKeepReason(ctx context.Context, owner, repo string, tag *tagWithSHA, position int, released map[string]bool) (string, error) {
+	name := tag.semver.Full
+	if position < a.config.GCKeepLast {
+		return fmt.Sprintf("within the last %d of its line", a.config.GCKeepLast), nil
+	}
+	for _, pattern := range a.config.GCExclude {
+		if ok, _ := path.Match(pattern, name); ok {
+			return fmt.Sprintf("excluded by %q", pattern), nil
+		}
+	}
+	if released[name] {
+		return "referenced by a GitHub release", nil
+	}
+	if a.config.GCMinAge > 0 {
+		date, err := a.commitDate(ctx, owner, repo, tag)
+		if err != nil {
+			return "", err
+		}
+		if age := a.now().Sub(date); age < a.config.GCMinAge {
+			return fmt.Sprintf("younger than %s", a.config.GCMinAge), nil
+		}
+	}
+	return "", nil
+}
+
+// gcLine returns the version line key of sv for the configured scope.
+func (a *Action) gcLine(sv *SemVer) string {
+	switch a.gcScope() {
+	case gcScopeMajor:
+		return a.scheme.MajorKey(sv)
+	case gcScopeMinor:
+		return a.scheme.MinorKey(sv)
+	default:
+		return fmt.Sprintf("%s.%s", a.scheme.MinorKey(sv), sv.Patch)
+	}
+}
+
+// gcScope returns the configured scope, defaulting to patch.
+func (a *Action) gcScope() string {
+	return valueOrDefault(a.config.GCScope, gcScopePatch)
+}
+
+// releaseTags returns the names of all tags referenced by GitHub releases.
+func (a *Action) releaseTags(ctx context.Context, owner, repo string) (map[string]bool, error) {
+	released := make(map[string]bool)
+	err := a.listAllReleases(ctx, owner, repo, func(release *github.RepositoryRelease) {
+		released[release.TagName] = true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return released, nil
+}
+
+// releaseDates returns the publication date of every published GitHub release by tag name.
+func (a *Action) releaseDates(ctx context.Context, owner, repo string) (map[string]time.Time, error) {
+	dates := make(map[string]time.Time)
+	err := a.listAllReleases(ctx, owner, repo, func(release *github.RepositoryRelease) {
+		if date := release.GetPublishedAt().Time; !date.IsZero() {
+			dates[release.TagName] = date
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dates, nil
+}
+
+// listAllReleases pages through all GitHub releases of the repository and calls fn for each of them.
+func (a *Action) listAllReleases(ctx context.Context, owner, repo string, fn func(release *github.RepositoryRelease)) error {
+	page := 1
+	for {
+		releases, resp, err := a.client.ListReleases(ctx, owner, repo, &github.ListOptions{
+			Page:    page,
+			PerPage: 100,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list releases (page %d): %w", page, err)
+		}
+		for _, release := range releases {
+			fn(release)
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		page = resp.NextPage
+	}
+}
+
+// validateGCScope checks that a scope option holds a supported value.
+func validateGCScope(value string) error {
+	switch value {
+	case "", gcScopePatch, gcScopeMinor, gcScopeMajor:
+		return nil
+	default:
+		return fmt.Errorf("invalid --gc-scope %q (expected %s, %s or %s)", value, gcScopePatch, gcScopeMinor, gcScopeMajor)
+	}
+}
+
+// parseAge parses a Go duration or a number of days such as "30d".
+func parseAge(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid age %q (expected e.g. 30d or 720h)", value)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid age %q (expected e.g. 30d or 720h)", value)
+	}
+	return d, nil
+}
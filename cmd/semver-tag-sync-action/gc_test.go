@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v90/github"
+)
+
+func TestActionRunGC(t *testing.T) {
+	now := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		config      Config
+		releases    []string
+		wantDeleted []string
+	}{
+		{
+			name:        "patch scope deletes superseded candidates",
+			config:      Config{},
+			wantDeleted: []string{"tags/v1.2.3-rc.2", "tags/v1.2.3-rc.1"},
+		},
+		{
+			name:        "major scope",
+			config:      Config{GCScope: gcScopeMajor},
+			wantDeleted: []string{"tags/v1.3.0-rc.1", "tags/v1.2.4-beta.1", "tags/v1.2.3-rc.2", "tags/v1.2.3-rc.1"},
+		},
+		{
+			name:        "keep last",
+			config:      Config{GCKeepLast: 1},
+			wantDeleted: []string{"tags/v1.2.3-rc.1"},
+		},
+		{
+			name:        "exclude patterns",
+			config:      Config{GCExclude: []string{"*-rc.2"}},
+			wantDeleted: []string{"tags/v1.2.3-rc.1"},
+		},
+		{
+			name:        "tags referenced by releases are kept",
+			config:      Config{},
+			releases:    []string{"v1.2.3-rc.1"},
+			wantDeleted: []string{"tags/v1.2.3-rc.2"},
+		},
+		{
+			name:        "min age",
+			config:      Config{GCMinAge: 30 * 24 * time.Hour},
+			wantDeleted: []string{"tags/v1.2.3-rc.1"},
+		},
+		{
+			name:   "dry run",
+			config: Config{DryRun: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deleted []string
+			mock := &mockGitHubClient{
+				listTagsFunc: func(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryTag, *github.Response, error) {
+					return []*github.RepositoryTag{
+						makeTag("v1.2.3-rc.1", "sha123rc1"),
+						makeTag("v1.2.3-rc.2", "sha123rc2"),
+						makeTag("v1.2.3", "sha123"),
+						makeTag("v1.2.4-beta.1", "sha124b1"),
+						makeTag("v1.3.0-rc.1", "sha130rc1"),
+						makeTag("v1.4.0", "sha140"),
+						makeTag("v1", "sha140"),
+					}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+				},
+				listReleasesFunc: func(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryRelease, *github.Response, error) {
+					var releases []*github.RepositoryRelease
+					for _, name := range tt.releases {
+						releases = append(releases, &github.RepositoryRelease{TagName: name})
+					}
+					return releases, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+				},
+				getCommitFunc: func(ctx context.Context, owner, repo, sha string) (*github.Commit, *github.Response, error) {
+					date := now.AddDate(0, 0, -60)
+					if sha == "sha123rc2" {
+						date = now.AddDate(0, 0, -10)
+					}
+					return &github.Commit{
+						Committer: &github.CommitAuthor{Date: &github.Timestamp{Time: date}},
+					}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+				},
+				getRefFunc: func(ctx context.Context, owner, repo, ref string) (*github.Reference, *github.Response, error) {
+					return &github.Reference{
+						Object: &github.GitObject{SHA: github.Ptr("sha")},
+					}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+				},
+				deleteRefFunc: func(ctx context.Context, owner, repo, ref string) (*github.Response, error) {
+					deleted = append(deleted, ref)
+					return &github.Response{Response: &http.Response{StatusCode: http.StatusNoContent}}, nil
+				},
+			}
+
+			config := tt.config
+			config.GitHubRepo = "owner/repo"
+			config.GCPrereleases = true
+
+			var out bytes.Buffer
+			action := NewAction(mock, config, nil)
+			action.out = &out
+			action.now = func() time.Time { return now }
+			if err := action.Run(context.Background()); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+
+			if !slices.Equal(deleted, tt.wantDeleted) {
+				t.Errorf("deleted = %v, want %v", deleted, tt.wantDeleted)
+			}
+			if config.DryRun && !strings.Contains(out.String(), "delete\tv1.2.3-rc.1\tsuperseded by v1.2.3") {
+				t.Errorf("expected dry-run plan, got %q", out.String())
+			}
+		})
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"30d", 30 * 24 * time.Hour, false},
+		{"720h", 720 * time.Hour, false},
+		{"-1d", 0, true},
+		{"soon", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseAge(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseAge(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("parseAge(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -13,7 +13,20 @@ type GitHubClient interface {
 	GetRef(ctx context.Context, owner, repo, ref string) (*github.Reference, *github.Response, error)
 	CreateRef(ctx context.Context, owner, repo string, ref github.CreateRef) (*github.Reference, *github.Response, error)
 	UpdateRef(ctx context.Context, owner, repo, ref string, updateRef github.UpdateRef) (*github.Reference, *github.Response, error)
+	DeleteRef(ctx context.Context, owner, repo, ref string) (*github.Response, error)
 	ListTags(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryTag, *github.Response, error)
+	ListReleases(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryRelease, *github.Response, error)
+	GetCommit(ctx context.Context, owner, repo, sha string) (*github.Commit, *github.Response, error)
+	GetBlobRaw(ctx context.Context, owner, repo, sha string) ([]byte, *github.Response, error)
+	GetContents(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error)
+	CreateFile(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentFileOptions) (*github.RepositoryContentResponse, *github.Response, error)
+	UpdateFile(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentFileOptions) (*github.RepositoryContentResponse, *github.Response, error)
+	CreateTree(ctx context.Context, owner, repo, baseTree string, entries []*github.TreeEntry) (*github.Tree, *github.Response, error)
+	CreateCommit(ctx context.Context, owner, repo string, commit github.Commit, opts *github.CreateCommitOptions) (*github.Commit, *github.Response, error)
+	ListIssues(ctx context.Context, owner, repo string, opts *github.IssueListByRepoOptions) ([]*github.Issue, *github.Response, error)
+	CreateIssue(ctx context.Context, owner, repo string, issue github.CreateIssueRequest) (*github.Issue, *github.Response, error)
+	UpdateIssue(ctx context.Context, owner, repo string, number int, issue github.UpdateIssueRequest) (*github.Issue, *github.Response, error)
+	CreateIssueComment(ctx context.Context, owner, repo string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error)
 }
 
 // gitHubClientWrapper wraps the go-github client to implement GitHubClient.
@@ -46,10 +59,62 @@ func (g *gitHubClientWrapper) UpdateRef(ctx context.Context, owner, repo, ref st
 	return g.client.Git.UpdateRef(ctx, owner, repo, ref, updateRef)
 }
 
+func (g *gitHubClientWrapper) DeleteRef(ctx context.Context, owner, repo, ref string) (*github.Response, error) {
+	return g.client.Git.DeleteRef(ctx, owner, repo, ref)
+}
+
 func (g *gitHubClientWrapper) ListTags(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryTag, *github.Response, error) {
 	return g.client.Repositories.ListTags(ctx, owner, repo, opts)
 }
 
+func (g *gitHubClientWrapper) ListReleases(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryRelease, *github.Response, error) {
+	return g.client.Repositories.ListReleases(ctx, owner, repo, opts)
+}
+
+func (g *gitHubClientWrapper) GetCommit(ctx context.Context, owner, repo, sha string) (*github.Commit, *github.Response, error) {
+	return g.client.Git.GetCommit(ctx, owner, repo, sha)
+}
+
+func (g *gitHubClientWrapper) GetBlobRaw(ctx context.Context, owner, repo, sha string) ([]byte, *github.Response, error) {
+	return g.client.Git.GetBlobRaw(ctx, owner, repo, sha)
+}
+
+func (g *gitHubClientWrapper) GetContents(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error) {
+	return g.client.Repositories.GetContents(ctx, owner, repo, path, opts)
+}
+
+func (g *gitHubClientWrapper) CreateFile(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentFileOptions) (*github.RepositoryContentResponse, *github.Response, error) {
+	return g.client.Repositories.CreateFile(ctx, owner, repo, path, opts)
+}
+
+func (g *gitHubClientWrapper) UpdateFile(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentFileOptions) (*github.RepositoryContentResponse, *github.Response, error) {
+	return g.client.Repositories.UpdateFile(ctx, owner, repo, path, opts)
+}
+
+func (g *gitHubClientWrapper) CreateTree(ctx context.Context, owner, repo, baseTree string, entries []*github.TreeEntry) (*github.Tree, *github.Response, error) {
+	return g.client.Git.CreateTree(ctx, owner, repo, baseTree, entries)
+}
+
+func (g *gitHubClientWrapper) CreateCommit(ctx context.Context, owner, repo string, commit github.Commit, opts *github.CreateCommitOptions) (*github.Commit, *github.Response, error) {
+	return g.client.Git.CreateCommit(ctx, owner, repo, commit, opts)
+}
+
+func (g *gitHubClientWrapper) ListIssues(ctx context.Context, owner, repo string, opts *github.IssueListByRepoOptions) ([]*github.Issue, *github.Response, error) {
+	return g.client.Issues.ListByRepo(ctx, owner, repo, opts)
+}
+
+func (g *gitHubClientWrapper) CreateIssue(ctx context.Context, owner, repo string, issue github.CreateIssueRequest) (*github.Issue, *github.Response, error) {
+	return g.client.Issues.Create(ctx, owner, repo, issue)
+}
+
+func (g *gitHubClientWrapper) UpdateIssue(ctx context.Context, owner, repo string, number int, issue github.UpdateIssueRequest) (*github.Issue, *github.Response, error) {
+	return g.client.Issues.Update(ctx, owner, repo, number, issue)
+}
+
+func (g *gitHubClientWrapper) CreateIssueComment(ctx context.Context, owner, repo string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+	return g.client.Issues.CreateComment(ctx, owner, repo, number, comment)
+}
+
 // extractTagFromRef extracts the tag name from a git ref.
 func extractTagFromRef(ref string) (string, error) {
 	if !strings.HasPrefix(ref, "refs/tags/") {
@@ -0,0 +1,363 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v90/github"
+)
+
+// historyFile is the path of the JSON Lines move log on the history branch.
+const historyFile = "history.jsonl"
+
+// historyMaxAttempts bounds the retries when a concurrent run updates the log first.
+const historyMaxAttempts = 3
+
+// errHistoryConflict is returned when the history branch moved while appending.
+var errHistoryConflict = errors.New("history branch was updated concurrently")
+
+// historyEntry records a single floating tag move.
+type historyEntry struct {
+	Time        time.Time `json:"time"`
+	Ref         string    `json:"ref"`
+	FromSHA     string    `json:"from_sha,omitempty"`
+	FromRelease string    `json:"from_release,omitempty"`
+	ToSHA       string    `json:"to_sha"`
+	ToRelease   string    `json:"to_release,omitempty"`
+	Workflow    string    `json:"workflow,omitempty"`
+	RunID       string    `json:"run_id,omitempty"`
+	RunURL      string    `json:"run_url,omitempty"`
+}
+
+// historyLog is the parsed content of the history file and the blob SHA it was read at.
+type historyLog struct {
+	entries      []historyEntry
+	raw          []byte
+	fileSHA      string
+	branchExists bool
+}
+
+// recordMove remembers a floating tag move so it can be appended to the history log.
+func (a *Action) recordMove(ref, fromSHA, toSHA, release string) {
+	if !a.config.RecordHistory {
+		return
+	}
+	a.moves = append(a.moves, historyEntry{
+		Time:      a.now().UTC(),
+		Ref:       ref,
+		FromSHA:   fromSHA,
+		ToSHA:     toSHA,
+		ToRelease: release,
+		Workflow:  a.config.WorkflowName,
+		RunID:     a.config.RunID,
+		RunURL:    a.config.RunURL,
+	})
+}
+
+// writeHistory appends all recorded moves to the history branch in a single commit.
+func (a *Action) writeHistory(ctx context.Context, owner, repo string) error {
+	if !a.config.RecordHistory || len(a.moves) == 0 {
+		return nil
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := a.appendHistory(ctx, owner, repo)
+		if err == nil {
+			a.log.Info("Recorded floating tag moves",
+				slog.String("branch", a.config.HistoryBranch),
+				slog.Int("moves", len(a.moves)),
+			)
+			a.moves = nil
+			return nil
+		}
+		if !errors.Is(err, errHistoryConflict) || attempt == historyMaxAttempts {
+			return fmt.Errorf("failed to record history on branch %s: %w", a.config.HistoryBranch, err)
+		}
+		a.log.Debug("History branch changed concurrently, retrying",
+			slog.String("branch", a.config.HistoryBranch),
+			slog.Int("attempt", attempt),
+		)
+	}
+}
+
+// appendHistory reads the current log, appends the recorded moves and writes it back.
+func (a *Action) appendHistory(ctx context.Context, owner, repo string) error {
+	hist, err := a.readHistory(ctx, owner, repo)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	buf.Write(hist.raw)
+	if buf.Len() > 0 && !bytes.HasSuffix(hist.raw, []byte("\n")) {
+		buf.WriteByte('\n')
+	}
+	entries := hist.entries
+	for _, move := range a.moves {
+		if prev := lastHistoryEntry(entries, move.Ref, time.Time{}); prev != nil && prev.ToSHA == move.FromSHA {
+			move.FromRelease = prev.ToRelease
+		}
+		line, err := json.Marshal(move)
+		if err != nil {
+			return fmt.Errorf("failed to encode history entry: %w", err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+		entries = append(entries, move)
+	}
+
+	message := fmt.Sprintf("Record %d floating tag move(s)", len(a.moves))
+
+	if !hist.branchExists {
+		return a.createHistoryBranch(ctx, owner, repo, buf.String(), message)
+	}
+
+	opts := &github.RepositoryContentFileOptions{
+		Message: github.Ptr(message),
+		Content: buf.Bytes(),
+		Branch:  github.Ptr(a.config.HistoryBranch),
+	}
+	var resp *github.Response
+	if hist.fileSHA == "" {
+		_, resp, err = a.client.CreateFile(ctx, owner, repo, historyFile, opts)
+	} else {
+		opts.SHA = github.Ptr(hist.fileSHA)
+		_, resp, err = a.client.UpdateFile(ctx, owner, repo, historyFile, opts)
+	}
+	if err != nil {
+		if isConflict(resp) {
+			return errHistoryConflict
+		}
+		return fmt.Errorf("failed to write %s: %w", historyFile, err)
+	}
+	return nil
+}
+
+// createHistoryBranch creates the history branch as an orphan commit holding only the log.
+func (a *Action) createHistoryBranch(ctx context.Context, owner, repo, content, message string) error {
+	tree, _, err := a.client.CreateTree(ctx, owner, repo, "", []*github.TreeEntry{{
+		Path:    github.Ptr(historyFile),
+		Mode:    github.Ptr("100644"),
+		Type:    github.Ptr("blob"),
+		Content: github.Ptr(content),
+	}})
+	if err != nil {
+		return fmt.Errorf("failed to create history tree: %w", err)
+	}
+
+	commit, _, err := a.client.CreateCommit(ctx, owner, repo, github.Commit{
+		Message: github.Ptr(message),
+		Tree:    &github.Tree{SHA: tree.SHA},
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create history commit: %w", err)
+	}
+
+	_, resp, err := a.client.CreateRef(ctx, owner, repo, github.CreateRef{
+		Ref: "refs/heads/" + a.config.HistoryBranch,
+		SHA: commit.GetSHA(),
+	})
+	if err != nil {
+		if isConflict(resp) {
+			return errHistoryConflict
+		}
+		return fmt.Errorf("failed to create history branch: %w", err)
+	}
+
+	a.log.Info("Created history branch",
+		slog.String("branch", a.config.HistoryBranch),
+	)
+	return nil
+}
+
+// readHistory fetches and parses the history log from the history branch.
+func (a *Action) readHistory(ctx context.Context, owner, repo string) (*historyLog, error) {
+	file, _, resp, err := a.client.GetContents(ctx, owner, repo, historyFile, &github.RepositoryContentGetOptions{
+		Ref: a.config.HistoryBranch,
+	})
+	if err != nil {
+		if resp == nil || resp.StatusCode != http.StatusNotFound {
+			return nil, fmt.Errorf("failed to read %s: %w", historyFile, err)
+		}
+		_, resp, err := a.client.GetRef(ctx, owner, repo, "heads/"+a.config.HistoryBranch)
+		if err != nil {
+			if resp == nil || resp.StatusCode != http.StatusNotFound {
+				return nil, fmt.Errorf("failed to check history branch %s: %w", a.config.HistoryBranch, err)
+			}
+			return &historyLog{}, nil
+		}
+		return &historyLog{branchExists: true}, nil
+	}
+
+	content, err := a.historyFileContent(ctx, owner, repo, file)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := parseHistory(content)
+	if err != nil {
+		return nil, err
+	}
+	return &historyLog{
+		entries:      entries,
+		raw:          []byte(content),
+		fileSHA:      file.GetSHA(),
+		branchExists: true,
+	}, nil
+}
+
+// historyFileContent returns the content of the history log. The Contents API
+// omits the content of files over 1 MB, so those are read through the blob API.
+func (a *Action) historyFileContent(ctx context.Context, owner, repo string, file *github.RepositoryContent) (string, error) {
+	if file.GetEncoding() != "none" {
+		content, err := file.GetContent()
+		if err != nil {
+			return "", fmt.Errorf("failed to decode %s: %w", historyFile, err)
+		}
+		return content, nil
+	}
+	blob, _, err := a.client.GetBlobRaw(ctx, owner, repo, file.GetSHA())
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s blob %s: %w", historyFile, file.GetSHA(), err)
+	}
+	return string(blob), nil
+}
+
+// parseHistory decodes a JSON Lines history log.
+func parseHistory(content string) ([]historyEntry, error) {
+	var entries []historyEntry
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var entry historyEntry
+		if err := json.Unmarshal([]byte(text), &entry); err != nil {
+			return nil, fmt.Errorf("invalid history entry on line %d: %w", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+	return entries, nil
+}
+
+// lastHistoryEntry returns the most recent entry for ref, optionally not later than at.
+func lastHistoryEntry(entries []historyEntry, ref string, at time.Time) *historyEntry {
+	var last *historyEntry
+	for i := range entries {
+		entry := &entries[i]
+		if entry.Ref != ref {
+			continue
+		}
+		if !at.IsZero() && entry.Time.After(at) {
+			continue
+		}
+		if last == nil || !entry.Time.Before(last.Time) {
+			last = entry
+		}
+	}
+	return last
+}
+
+// runHistory prints the recorded floating tag moves, optionally the state of one tag at a point in time.
+func (a *Action) runHistory(ctx context.Context) error {
+	owner, repo, err := parseRepository(a.config.GitHubRepo)
+	if err != nil {
+		return err
+	}
+
+	hist, err := a.readHistory(ctx, owner, repo)
+	if err != nil {
+		return err
+	}
+
+	ref := ""
+	if a.config.HistoryTag != "" {
+		ref = historyRef(a.config.HistoryTag)
+	}
+
+	if a.config.HistoryAt != "" {
+		at, err := parseHistoryTime(a.config.HistoryAt)
+		if err != nil {
+			return err
+		}
+		entry := lastHistoryEntry(hist.entries, ref, at)
+		if entry == nil {
+			return fmt.Errorf("no recorded moves for %s at or before %s", a.config.HistoryTag, at.Format(time.RFC3339))
+		}
+		fmt.Fprintf(a.out, "%s pointed to %s at %s (moved %s)\n",
+			shortRefName(entry.Ref), formatRelease(entry.ToSHA, entry.ToRelease),
+			at.Format(time.RFC3339), entry.Time.Format(time.RFC3339))
+		if entry.RunURL != "" {
+			fmt.Fprintf(a.out, "moved by %s\n", entry.RunURL)
+		}
+		return nil
+	}
+
+	for _, entry := range hist.entries {
+		if ref != "" && entry.Ref != ref {
+			continue
+		}
+		fmt.Fprintf(a.out, "%s\t%s\t%s -> %s",
+			entry.Time.Format(time.RFC3339), shortRefName(entry.Ref),
+			formatRelease(entry.FromSHA, entry.FromRelease), formatRelease(entry.ToSHA, entry.ToRelease))
+		if entry.RunURL != "" {
+			fmt.Fprintf(a.out, "\t%s", entry.RunURL)
+		}
+		fmt.Fprintln(a.out)
+	}
+	return nil
+}
+
+// historyRef converts a tag name or full ref into the full ref stored in the log.
+func historyRef(name string) string {
+	if strings.HasPrefix(name, "refs/") {
+		return name
+	}
+	return "refs/tags/" + name
+}
+
+// shortRefName strips the refs/tags/ prefix for display; branches keep their heads/ namespace.
+func shortRefName(ref string) string {
+	return refDisplayName(strings.TrimPrefix(ref, "refs/"))
+}
+
+// formatRelease renders a SHA with its release name, if known.
+func formatRelease(sha, release string) string {
+	if sha == "" {
+		return "(none)"
+	}
+	if release == "" {
+		return sha
+	}
+	return fmt.Sprintf("%s (%s)", sha, release)
+}
+
+// parseHistoryTime parses an RFC 3339 timestamp or a YYYY-MM-DD date meaning the end of that day in UTC.
+func parseHistoryTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	day, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid history time %q (expected RFC 3339 or YYYY-MM-DD)", value)
+	}
+	return day.Add(24*time.Hour - time.Nanosecond), nil
+}
+
+// isConflict reports whether the response indicates a concurrent modification.
+func isConflict(resp *github.Response) bool {
+	return resp != nil && (resp.StatusCode == http.StatusConflict || resp.StatusCode == http.StatusUnprocessableEntity)
+}
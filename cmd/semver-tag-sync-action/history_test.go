@@ -0,0 +1,289 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v90/github"
+)
+
+func historyContent(lines ...string) *github.RepositoryContent {
+	return &github.RepositoryContent{
+		SHA:      github.Ptr("filesha"),
+		Encoding: github.Ptr("base64"),
+		Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte(strings.Join(lines, "\n") + "\n"))),
+	}
+}
+
+func TestActionRun_RecordHistoryCreatesBranch(t *testing.T) {
+	var treeContent, branchRef string
+	mock := &mockGitHubClient{
+		createTreeFunc: func(ctx context.Context, owner, repo, baseTree string, entries []*github.TreeEntry) (*github.Tree, *github.Response, error) {
+			if baseTree != "" {
+				t.Errorf("expected orphan tree, got base tree %q", baseTree)
+			}
+			treeContent = entries[0].GetContent()
+			return &github.Tree{SHA: github.Ptr("tree")}, &github.Response{Response: &http.Response{StatusCode: http.StatusCreated}}, nil
+		},
+		createRefFunc: func(ctx context.Context, owner, repo string, ref github.CreateRef) (*github.Reference, *github.Response, error) {
+			if strings.HasPrefix(ref.Ref, "refs/heads/") {
+				branchRef = ref.Ref
+			}
+			return &github.Reference{}, &github.Response{Response: &http.Response{StatusCode: http.StatusCreated}}, nil
+		},
+	}
+
+	config := Config{
+		GitHubRepo:    "owner/repo",
+		GitRef:        "refs/tags/v1.2.3",
+		CommitSHA:     "abc123",
+		SyncMajor:     true,
+		SyncMinor:     true,
+		RecordHistory: true,
+		HistoryBranch: "semver-tag-sync/history",
+		RunURL:        "https://github.com/owner/repo/actions/runs/1",
+	}
+
+	action := NewAction(mock, config, nil)
+	if err := action.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if branchRef != "refs/heads/semver-tag-sync/history" {
+		t.Errorf("expected history branch to be created, got %q", branchRef)
+	}
+	entries, err := parseHistory(treeContent)
+	if err != nil {
+		t.Fatalf("parseHistory() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 history entries, got %d", len(entries))
+	}
+	for _, entry := range entries {
+		if entry.ToSHA != "abc123" || entry.ToRelease != "v1.2.3" || entry.FromSHA != "" {
+			t.Errorf("unexpected history entry: %+v", entry)
+		}
+		if entry.RunURL != config.RunURL {
+			t.Errorf("expected run URL %q, got %q", config.RunURL, entry.RunURL)
+		}
+	}
+}
+
+func TestActionRun_RecordHistoryAppendsWithFromRelease(t *testing.T) {
+	var written string
+	attempts := 0
+	mock := &mockGitHubClient{
+		getRefFunc: func(ctx context.Context, owner, repo, ref string) (*github.Reference, *github.Response, error) {
+			return &github.Reference{
+				Object: &github.GitObject{SHA: github.Ptr("old")},
+			}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+		},
+		getContentsFunc: func(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error) {
+			return historyContent(
+				`{"time":"2026-01-01T00:00:00Z","ref":"refs/tags/v1","to_sha":"old","to_release":"v1.2.2"}`,
+			), nil, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+		},
+		updateFileFunc: func(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentFileOptions) (*github.RepositoryContentResponse, *github.Response, error) {
+			attempts++
+			if attempts == 1 {
+				return nil, &github.Response{Response: &http.Response{StatusCode: http.StatusConflict}}, errors.New("conflict")
+			}
+			if opts.GetSHA() != "filesha" {
+				t.Errorf("expected file SHA guard, got %q", opts.GetSHA())
+			}
+			written = string(opts.Content)
+			return &github.RepositoryContentResponse{}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+		},
+	}
+
+	config := Config{
+		GitHubRepo:    "owner/repo",
+		GitRef:        "refs/tags/v1.2.3",
+		CommitSHA:     "new",
+		SyncMajor:     true,
+		RecordHistory: true,
+		HistoryBranch: "semver-tag-sync/history",
+	}
+
+	action := NewAction(mock, config, nil)
+	if err := action.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if attempts != 2 {
+		t.Errorf("expected a retry after the conflict, got %d attempts", attempts)
+	}
+	entries, err := parseHistory(written)
+	if err != nil {
+		t.Fatalf("parseHistory() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 history entries, got %d", len(entries))
+	}
+	last := entries[1]
+	if last.FromSHA != "old" || last.FromRelease != "v1.2.2" || last.ToSHA != "new" || last.ToRelease != "v1.2.3" {
+		t.Errorf("unexpected history entry: %+v", last)
+	}
+}
+
+func TestActionRun_RecordHistoryReadsLargeLogFromBlob(t *testing.T) {
+	old := `{"time":"2026-01-01T00:00:00Z","ref":"refs/tags/v1","to_sha":"old","to_release":"v1.2.2"}` + "\n"
+	var written string
+	mock := &mockGitHubClient{
+		getRefFunc: func(ctx context.Context, owner, repo, ref string) (*github.Reference, *github.Response, error) {
+			return &github.Reference{
+				Object: &github.GitObject{SHA: github.Ptr("old")},
+			}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+		},
+		getContentsFunc: func(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error) {
+			// Files over 1 MB come back without inline content.
+			return &github.RepositoryContent{
+				SHA:      github.Ptr("filesha"),
+				Size:     github.Ptr(2 << 20),
+				Encoding: github.Ptr("none"),
+				Content:  github.Ptr(""),
+			}, nil, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+		},
+		getBlobRawFunc: func(ctx context.Context, owner, repo, sha string) ([]byte, *github.Response, error) {
+			if sha != "filesha" {
+				t.Errorf("expected blob filesha, got %q", sha)
+			}
+			return []byte(old), &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+		},
+		updateFileFunc: func(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentFileOptions) (*github.RepositoryContentResponse, *github.Response, error) {
+			written = string(opts.Content)
+			return &github.RepositoryContentResponse{}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+		},
+	}
+
+	config := Config{
+		GitHubRepo:    "owner/repo",
+		GitRef:        "refs/tags/v1.2.3",
+		CommitSHA:     "new",
+		SyncMajor:     true,
+		RecordHistory: true,
+		HistoryBranch: "semver-tag-sync/history",
+	}
+
+	action := NewAction(mock, config, nil)
+	if err := action.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if !strings.HasPrefix(written, old) {
+		t.Errorf("expected the existing log to be kept, got %q", written)
+	}
+	entries, err := parseHistory(written)
+	if err != nil {
+		t.Fatalf("parseHistory() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 history entries, got %d", len(entries))
+	}
+	if last := entries[1]; last.FromRelease != "v1.2.2" || last.ToRelease != "v1.2.3" {
+		t.Errorf("unexpected history entry: %+v", last)
+	}
+}
+
+func TestActionRun_DryRunDoesNotRecordHistory(t *testing.T) {
+	mock := &mockGitHubClient{
+		getContentsFunc: func(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error) {
+			t.Error("history should not be read in dry-run mode")
+			return nil, nil, nil, nil
+		},
+	}
+
+	config := Config{
+		GitHubRepo:    "owner/repo",
+		GitRef:        "refs/tags/v1.2.3",
+		CommitSHA:     "abc123",
+		SyncMajor:     true,
+		DryRun:        true,
+		RecordHistory: true,
+		HistoryBranch: "semver-tag-sync/history",
+	}
+
+	action := NewAction(mock, config, nil)
+	if err := action.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+}
+
+func TestActionRun_ShowHistoryAt(t *testing.T) {
+	mock := &mockGitHubClient{
+		getContentsFunc: func(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error) {
+			if opts.Ref != "semver-tag-sync/history" {
+				t.Errorf("expected history branch ref, got %q", opts.Ref)
+			}
+			return historyContent(
+				`{"time":"2026-10-01T10:00:00Z","ref":"refs/tags/v2","to_sha":"sha230","to_release":"v2.3.0"}`,
+				`{"time":"2026-10-12T10:00:00Z","ref":"refs/tags/v1","to_sha":"sha150","to_release":"v1.5.0"}`,
+				`{"time":"2026-10-15T10:00:00Z","ref":"refs/tags/v2","from_sha":"sha230","to_sha":"sha240","to_release":"v2.4.0"}`,
+			), nil, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+		},
+	}
+
+	config := Config{
+		GitHubRepo:    "owner/repo",
+		SyncMajor:     true,
+		ShowHistory:   true,
+		HistoryBranch: "semver-tag-sync/history",
+		HistoryTag:    "v2",
+		HistoryAt:     "2026-10-13",
+	}
+
+	var out bytes.Buffer
+	action := NewAction(mock, config, nil)
+	action.out = &out
+	if err := action.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if !strings.Contains(out.String(), "v2 pointed to sha230 (v2.3.0)") {
+		t.Errorf("unexpected output: %q", out.String())
+	}
+}
+
+func TestParseHistoryTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name:  "rfc3339",
+			value: "2026-10-13T12:00:00Z",
+			want:  time.Date(2026, 10, 13, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "date means end of day",
+			value: "2026-10-13",
+			want:  time.Date(2026, 10, 13, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:    "invalid",
+			value:   "last tuesday",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseHistoryTime(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseHistoryTime() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseHistoryTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
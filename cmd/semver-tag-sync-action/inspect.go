@@ -0,0 +1,222 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats of the inspect mode selectable with --inspect-format.
+const (
+	inspectFormatTable = "table"
+	inspectFormatJSON  = "json"
+	inspectFormatYAML  = "yaml"
+	inspectFormatCSV   = "csv"
+)
+
+// Statuses of a floating ref reported by inspect besides the drift statuses.
+const (
+	lineInSync      = "in-sync"
+	lineFrozen      = "frozen"
+	lineUnsupported = "unsupported"
+)
+
+// lineInfo describes a floating ref of a version line and the release it should point to.
+type lineInfo struct {
+	Level        string    `json:"level" yaml:"level"`
+	Line         string    `json:"line" yaml:"line"`
+	Ref          string    `json:"ref" yaml:"ref"`
+	Latest       string    `json:"latest" yaml:"latest"`
+	LatestSHA    string    `json:"latest_sha" yaml:"latest_sha"`
+	CurrentSHA   string    `json:"current_sha,omitempty" yaml:"current_sha,omitempty"`
+	InSync       bool      `json:"in_sync" yaml:"in_sync"`
+	Status       string    `json:"status" yaml:"status"`
+	Releases     int       `json:"releases" yaml:"releases"`
+	LastReleased time.Time `json:"last_released" yaml:"last_released"`
+}
+
+// runInspect prints every version line with its latest release and the current target of its floating refs.
+func (a *Action) runInspect(ctx context.Context) error {
+	owner, repo, err := parseRepository(a.config.GitHubRepo)
+	if err != nil {
+		return err
+	}
+	lines, err := a.inspect(ctx, owner, repo)
+	if err != nil {
+		return err
+	}
+	return a.printLines(lines)
+}
+
+// inspect collects the floating refs of every version line in level order and by ascending version.
+func (a *Action) inspect(ctx context.Context, owner, repo string) ([]lineInfo, error) {
+	groups, err := a.collectLatestTags(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	unsupported := a.unsupportedLines(groups)
+	published, err := a.releaseDates(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []lineInfo
+	for _, level := range a.levels() {
+		tagMap := groups[level]
+		keys := slices.SortedFunc(maps.Keys(tagMap), func(x, y string) int {
+			if c := a.scheme.Compare(tagMap[x].semver, tagMap[y].semver); c != 0 {
+				return c
+			}
+			return strings.Compare(x, y)
+		})
+		for _, key := range keys {
+			latest := tagMap[key]
+			refs := a.floatingRefs(level, latest.semver)
+			if len(refs) == 0 {
+				continue
+			}
+			date, err := a.lastReleased(ctx, owner, repo, latest, a.members[level][key], published)
+			if err != nil {
+				return nil, err
+			}
+			for _, ref := range refs {
+				info := lineInfo{
+					Level:        string(level),
+					Line:         key,
+					Ref:          ref,
+					Releases:     len(a.members[level][key]),
+					LastReleased: date,
+				}
+				entry, err := a.targetRelease(ref, latest)
+				if err != nil {
+					return nil, fmt.Errorf("failed to inspect %s %s %s: %w", level, refKind(ref), refDisplayName(ref), err)
+				}
+				info.Latest, info.LatestSHA = entry.semver.Full, entry.sha
+
+				if info.CurrentSHA, _, err = a.currentTarget(ctx, owner, repo, ref); err != nil {
+					return nil, fmt.Errorf("failed to get %s %s: %w", refKind(ref), refDisplayName(ref), err)
+				}
+				info.InSync = info.CurrentSHA == info.LatestSHA
+
+				switch {
+				case !level.repoWide() && unsupported[a.scheme.MajorKey(latest.semver)]:
+					info.Status = lineUnsupported
+				case a.holdReason(ref, entry.semver.Full) != "":
+					info.Status = lineFrozen
+				case info.InSync:
+					info.Status = lineInSync
+				case info.CurrentSHA == "":
+					info.Status = driftMissing
+				default:
+					info.Status = driftStale
+				}
+				lines = append(lines, info)
+			}
+		}
+	}
+
+	a.log.Debug("Inspected version lines",
+		slog.Int("refs", len(lines)),
+	)
+	return lines, nil
+}
+
+// lastReleased returns when a group last had a release: the newest publication date of the GitHub
+// releases of its members, which is not necessarily the date of the highest version, e.g. a v1.9.5
+// backport may follow v1.10.0. Without a GitHub release, the highest version is dated by its commit,
+// so a group costs at most one request instead of one per release tag.
+func (a *Action) lastReleased(ctx context.Context, owner, repo string, latest *tagWithSHA, members []*tagWithSHA, published map[string]time.Time) (time.Time, error) {
+	var last time.Time
+	for _, entry := range members {
+		if date := published[entry.semver.Full]; date.After(last) {
+			last = date
+		}
+	}
+	if _, ok := published[latest.semver.Full]; !ok {
+		date, err := a.commitDate(ctx, owner, repo, latest)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if date.After(last) {
+			last = date
+		}
+	}
+	return last, nil
+}
+
+// printLines writes the inspected version lines in the configured format.
+func (a *Action) printLines(lines []lineInfo) error {
+	switch valueOrDefault(a.config.InspectFormat, inspectFormatTable) {
+	case inspectFormatJSON:
+		enc := json.NewEncoder(a.out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(append([]lineInfo{}, lines...))
+	case inspectFormatYAML:
+		enc := yaml.NewEncoder(a.out)
+		enc.SetIndent(2)
+		if err := enc.Encode(append([]lineInfo{}, lines...)); err != nil {
+			return err
+		}
+		return enc.Close()
+	case inspectFormatCSV:
+		w := csv.NewWriter(a.out)
+		_ = w.Write([]string{"level", "line", "ref", "latest", "latest_sha", "current_sha", "in_sync", "status", "releases", "last_released"})
+		for _, l := range lines {
+			_ = w.Write([]string{
+				l.Level, l.Line, l.Ref, l.Latest, l.LatestSHA, l.CurrentSHA, strconv.FormatBool(l.InSync),
+				l.Status, strconv.Itoa(l.Releases), formatReleaseDate(l.LastReleased, time.RFC3339),
+			})
+		}
+		w.Flush()
+		return w.Error()
+	default:
+		w := tabwriter.NewWriter(a.out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "LINE\tREF\tLATEST\tCURRENT\tSTATUS\tRELEASES\tLAST-RELEASED")
+		for _, l := range lines {
+			current := shortSHA(l.CurrentSHA)
+			if current == "" {
+				current = "-"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s (%s)\t%s\t%s\t%d\t%s\n",
+				l.Line, refDisplayName(l.Ref), l.Latest, shortSHA(l.LatestSHA), current,
+				l.Status, l.Releases, formatReleaseDate(l.LastReleased, time.DateOnly))
+		}
+		return w.Flush()
+	}
+}
+
+// formatReleaseDate formats a release date, or returns "-" if it is unknown.
+func formatReleaseDate(t time.Time, layout string) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.UTC().Format(layout)
+}
+
+// shortSHA abbreviates a commit SHA for display.
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+// validateInspectFormat checks the output format of the inspect mode.
+func validateInspectFormat(format string) error {
+	switch format {
+	case "", inspectFormatTable, inspectFormatJSON, inspectFormatYAML, inspectFormatCSV:
+		return nil
+	default:
+		return fmt.Errorf("invalid --inspect-format %q (expected %s, %s, %s or %s)", format, inspectFormatTable, inspectFormatJSON, inspectFormatYAML, inspectFormatCSV)
+	}
+}
@@ -0,0 +1,256 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v90/github"
+	"gopkg.in/yaml.v3"
+)
+
+// inspectAction returns an action inspecting a repository with releases in the v1, v2 and v10 lines.
+func inspectAction(t *testing.T, config Config) (*Action, *bytes.Buffer) {
+	t.Helper()
+	mock := verifyMock(t, []*github.RepositoryTag{
+		makeTag("v1.0.0", "sha100"),
+		makeTag("v1.1.0", "sha110"),
+		makeTag("v1.1.1", "sha111"),
+		makeTag("v10.0.0", "sha1000"),
+		makeTag("v2.0.0", "sha200"),
+		makeTag("v2.1.0-rc.1", "sha210rc1"),
+	}, map[string]string{
+		"tags/v1":    "sha111",
+		"tags/v2":    "sha123",
+		"tags/v10":   "sha1000",
+		"tags/v1.0":  "sha100",
+		"tags/v1.1":  "sha111",
+		"tags/v10.0": "sha1000",
+	})
+	mock.getCommitFunc = func(ctx context.Context, owner, repo, sha string) (*github.Commit, *github.Response, error) {
+		date := time.Date(2026, 1, len(sha), 12, 0, 0, 0, time.UTC)
+		return &github.Commit{
+			Committer: &github.CommitAuthor{Date: &github.Timestamp{Time: date}},
+		}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+	}
+
+	config.GitHubRepo = "owner/repo"
+	config.Inspect = true
+	config.SyncMajor = true
+	config.SyncMinor = true
+	config.SkipPrereleases = true
+	var out bytes.Buffer
+	action := NewAction(mock, config, nil)
+	action.out = &out
+	return action, &out
+}
+
+func TestActionRunInspect_JSON(t *testing.T) {
+	action, out := inspectAction(t, Config{
+		InspectFormat:   inspectFormatJSON,
+		FrozenTags:      []string{"v10"},
+		SupportedMajors: "v2,v10",
+	})
+	if err := action.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	var lines []lineInfo
+	if err := json.Unmarshal(out.Bytes(), &lines); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+	}
+	var got []string
+	for _, l := range lines {
+		got = append(got, l.Line+"="+l.Latest+"/"+l.Status)
+	}
+	want := []string{
+		"v1=v1.1.1/unsupported",
+		"v2=v2.0.0/stale",
+		"v10=v10.0.0/frozen",
+		"v1.0=v1.0.0/unsupported",
+		"v1.1=v1.1.1/unsupported",
+		"v2.0=v2.0.0/missing",
+		"v10.0=v10.0.0/in-sync",
+	}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("lines = %v, want %v", got, want)
+	}
+
+	v1 := lines[0]
+	if v1.Level != "major" || v1.Ref != "tags/v1" || v1.LatestSHA != "sha111" || v1.CurrentSHA != "sha111" || !v1.InSync || v1.Releases != 3 {
+		t.Errorf("v1 = %+v, want 3 releases with tags/v1 in sync at sha111", v1)
+	}
+	if want := time.Date(2026, 1, 6, 12, 0, 0, 0, time.UTC); !v1.LastReleased.Equal(want) {
+		t.Errorf("v1 last released = %v, want %v", v1.LastReleased, want)
+	}
+	if v2 := lines[1]; v2.CurrentSHA != "sha123" || v2.InSync || v2.Releases != 1 {
+		t.Errorf("v2 = %+v, want 1 stable release and tags/v2 stale at sha123", v2)
+	}
+}
+
+func TestActionRunInspect_Table(t *testing.T) {
+	action, out := inspectAction(t, Config{Pins: map[string]string{"v1": "v1.0.0"}})
+	if err := action.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	rows := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(rows) != 8 {
+		t.Fatalf("table has %d rows, want a header and 7 lines:\n%s", len(rows), out.String())
+	}
+	if got := strings.Fields(rows[0]); strings.Join(got, " ") != "LINE REF LATEST CURRENT STATUS RELEASES LAST-RELEASED" {
+		t.Errorf("header = %q", rows[0])
+	}
+	// The pinned v1 is expected at its pin rather than the newest release.
+	if got, want := strings.Join(strings.Fields(rows[1]), " "), "v1 v1 v1.0.0 (sha100) sha111 stale 3 2026-01-06"; got != want {
+		t.Errorf("v1 row = %q, want %q", got, want)
+	}
+	if got, want := strings.Join(strings.Fields(rows[6]), " "), "v2.0 v2.0 v2.0.0 (sha200) - missing 1 2026-01-06"; got != want {
+		t.Errorf("v2.0 row = %q, want %q", got, want)
+	}
+}
+
+func TestActionRunInspect_CSV(t *testing.T) {
+	action, out := inspectAction(t, Config{InspectFormat: inspectFormatCSV})
+	if err := action.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	records, err := csv.NewReader(out).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not CSV: %v", err)
+	}
+	if len(records) != 8 || records[0][0] != "level" {
+		t.Fatalf("records = %v, want a header and 7 lines", records)
+	}
+	if got, want := strings.Join(records[2], ","), "major,v2,tags/v2,v2.0.0,sha200,sha123,false,stale,1,2026-01-06T12:00:00Z"; got != want {
+		t.Errorf("v2 record = %q, want %q", got, want)
+	}
+}
+
+func TestActionRunInspect_YAML(t *testing.T) {
+	action, out := inspectAction(t, Config{InspectFormat: inspectFormatYAML})
+	if err := action.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	var lines []lineInfo
+	if err := yaml.Unmarshal(out.Bytes(), &lines); err != nil {
+		t.Fatalf("output is not YAML: %v\n%s", err, out.String())
+	}
+	if len(lines) != 7 || lines[3].Line != "v1.0" || lines[3].Status != lineInSync || lines[3].Releases != 1 {
+		t.Errorf("lines = %+v, want v1.0 in sync with 1 release as the first minor line", lines)
+	}
+	if !strings.Contains(out.String(), "latest_sha: sha100") {
+		t.Errorf("output = %q, want snake_case keys", out.String())
+	}
+}
+
+func TestActionRunInspect_FormatsWithLogs(t *testing.T) {
+	decoders := map[string]func(data []byte) (int, error){
+		inspectFormatJSON: func(data []byte) (int, error) {
+			var lines []lineInfo
+			err := json.Unmarshal(data, &lines)
+			return len(lines), err
+		},
+		inspectFormatYAML: func(data []byte) (int, error) {
+			var lines []lineInfo
+			err := yaml.Unmarshal(data, &lines)
+			return len(lines), err
+		},
+		inspectFormatCSV: func(data []byte) (int, error) {
+			records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+			if err == nil && (len(records) == 0 || records[0][0] != "level") {
+				err = errors.New("missing header")
+			}
+			return len(records) - 1, err
+		},
+	}
+
+	for format, decode := range decoders {
+		t.Run(format, func(t *testing.T) {
+			action, _ := inspectAction(t, Config{InspectFormat: format})
+			commits := 0
+			mock := action.client.(*mockGitHubClient)
+			getCommit := mock.getCommitFunc
+			mock.getCommitFunc = func(ctx context.Context, owner, repo, sha string) (*github.Commit, *github.Response, error) {
+				commits++
+				return getCommit(ctx, owner, repo, sha)
+			}
+
+			stdout, stderr, err := runWithProcessOutput(t, mock, action.config)
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			n, err := decode([]byte(stdout))
+			if err != nil || n != 7 {
+				t.Fatalf("stdout has %d lines, error %v, want 7 lines:\n%s", n, err, stdout)
+			}
+			if !strings.Contains(stderr, "Fetched all tags") {
+				t.Errorf("stderr = %q, want the log lines", stderr)
+			}
+			// Only the highest version of each line is dated by its commit, once per release.
+			if commits != 4 {
+				t.Errorf("GetCommit called %d times, want once for each of the 4 latest releases", commits)
+			}
+		})
+	}
+}
+
+func TestActionRunInspect_LastReleasedBackport(t *testing.T) {
+	mock := verifyMock(t, []*github.RepositoryTag{
+		makeTag("v1.10.0", "sha1100"),
+		makeTag("v1.9.5", "sha195-backport"),
+	}, nil)
+	// The v1.9.5 backport was published after v1.10.0.
+	mock.listReleasesFunc = func(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryRelease, *github.Response, error) {
+		return []*github.RepositoryRelease{
+			{TagName: "v1.10.0", PublishedAt: &github.Timestamp{Time: time.Date(2026, 1, 7, 12, 0, 0, 0, time.UTC)}},
+			{TagName: "v1.9.5", PublishedAt: &github.Timestamp{Time: time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)}},
+		}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+	}
+	mock.getCommitFunc = func(ctx context.Context, owner, repo, sha string) (*github.Commit, *github.Response, error) {
+		t.Errorf("GetCommit(%s) called, want the dates of the GitHub releases", sha)
+		return nil, nil, errors.New("unexpected request")
+	}
+	config := Config{
+		GitHubRepo:    "owner/repo",
+		Inspect:       true,
+		InspectFormat: inspectFormatJSON,
+		SyncMajor:     true,
+	}
+	var out bytes.Buffer
+	action := NewAction(mock, config, nil)
+	action.out = &out
+	if err := action.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	var lines []lineInfo
+	if err := json.Unmarshal(out.Bytes(), &lines); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+	}
+	if len(lines) != 1 || lines[0].Latest != "v1.10.0" {
+		t.Fatalf("lines = %+v, want v1 at v1.10.0", lines)
+	}
+	if want := time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC); !lines[0].LastReleased.Equal(want) {
+		t.Errorf("v1 last released = %v, want %v of the v1.9.5 backport", lines[0].LastReleased, want)
+	}
+}
+
+func TestValidateInspectFormat(t *testing.T) {
+	for _, format := range []string{"", inspectFormatTable, inspectFormatJSON, inspectFormatYAML, inspectFormatCSV} {
+		if err := validateInspectFormat(format); err != nil {
+			t.Errorf("validateInspectFormat(%q) error = %v", format, err)
+		}
+	}
+	if err := validateInspectFormat("xml"); err == nil {
+		t.Error("validateInspectFormat(\"xml\") expected error")
+	}
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/google/go-github/v90/github"
+)
+
+// defaultDriftIssueLabel is the label marking the issue that reports floating refs out of sync.
+const defaultDriftIssueLabel = "floating-tag-drift"
+
+// driftIssueTitle is the title of the issue reporting floating refs out of sync.
+const driftIssueTitle = "Floating tags are out of sync"
+
+// syncDriftIssue opens or updates the drift issue if there is drift, and closes it otherwise.
+// The issue is found by its label, so it survives edits of its title and body.
+func (a *Action) syncDriftIssue(ctx context.Context, owner, repo string, drift []driftEntry) error {
+	label := valueOrDefault(a.config.DriftIssueLabel, defaultDriftIssueLabel)
+	issue, err := a.findDriftIssue(ctx, owner, repo, label)
+	if err != nil {
+		return err
+	}
+
+	if len(drift) == 0 {
+		if issue == nil {
+			return nil
+		}
+		return a.closeDriftIssue(ctx, owner, repo, issue)
+	}
+
+	body := a.driftIssueBody(drift)
+	if issue == nil {
+		created, _, err := a.client.CreateIssue(ctx, owner, repo, github.CreateIssueRequest{
+			Title:  driftIssueTitle,
+			Body:   github.Ptr(body),
+			Labels: []string{label},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to open drift issue: %w", err)
+		}
+		a.log.Info("Opened drift issue",
+			slog.Int("issue", created.GetNumber()),
+			slog.String("url", created.GetHTMLURL()),
+		)
+		return a.commentRun(ctx, owner, repo, created.GetNumber(), "Detected by")
+	}
+
+	if issue.GetBody() == body {
+		a.log.Info("Drift issue is up to date",
+			slog.Int("issue", issue.GetNumber()),
+		)
+		return nil
+	}
+	if _, _, err := a.client.UpdateIssue(ctx, owner, repo, issue.GetNumber(), github.UpdateIssueRequest{
+		Body: github.Ptr(body),
+	}); err != nil {
+		return fmt.Errorf("failed to update drift issue #%d: %w", issue.GetNumber(), err)
+	}
+	a.log.Info("Updated drift issue",
+		slog.Int("issue", issue.GetNumber()),
+		slog.String("url", issue.GetHTMLURL()),
+	)
+	return a.commentRun(ctx, owner, repo, issue.GetNumber(), "Drift changed, detected by")
+}
+
+// commentRun links the workflow run that opened or updated the drift issue in a comment. The
+// link stays out of the body, which would otherwise differ on every run.
+func (a *Action) commentRun(ctx context.Context, owner, repo string, number int, text string) error {
+	if a.config.RunURL == "" {
+		return nil
+	}
+	if _, _, err := a.client.CreateIssueComment(ctx, owner, repo, number, &github.IssueComment{
+		Body: github.Ptr(text + " " + a.config.RunURL + "."),
+	}); err != nil {
+		return fmt.Errorf("failed to comment on drift issue #%d: %w", number, err)
+	}
+	return nil
+}
+
+// findDriftIssue returns the open issue with the drift label, or nil if there is none.
+func (a *Action) findDriftIssue(ctx context.Context, owner, repo, label string) (*github.Issue, error) {
+	opts := &github.IssueListByRepoOptions{
+		State:       "open",
+		Labels:      []string{label},
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	for {
+		issues, resp, err := a.client.ListIssues(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list issues labeled %s: %w", label, err)
+		}
+		for _, issue := range issues {
+			// The issues API also lists pull requests.
+			if !issue.IsPullRequest() {
+				return issue, nil
+			}
+		}
+		if resp == nil || resp.NextPage == 0 {
+			return nil, nil
+		}
+		opts.ListOptions.Page = resp.NextPage
+	}
+}
+
+// closeDriftIssue comments that the floating refs are in sync again and closes the issue.
+func (a *Action) closeDriftIssue(ctx context.Context, owner, repo string, issue *github.Issue) error {
+	comment := "All floating refs are in sync again."
+	if a.config.RunURL != "" {
+		comment += " Verified by " + a.config.RunURL + "."
+	}
+	if _, _, err := a.client.CreateIssueComment(ctx, owner, repo, issue.GetNumber(), &github.IssueComment{
+		Body: github.Ptr(comment),
+	}); err != nil {
+		return fmt.Errorf("failed to comment on drift issue #%d: %w", issue.GetNumber(), err)
+	}
+	if _, _, err := a.client.UpdateIssue(ctx, owner, repo, issue.GetNumber(), github.UpdateIssueRequest{
+		State:       github.Ptr("closed"),
+		StateReason: github.Ptr("completed"),
+	}); err != nil {
+		return fmt.Errorf("failed to close drift issue #%d: %w", issue.GetNumber(), err)
+	}
+	a.log.Info("Closed drift issue",
+		slog.Int("issue", issue.GetNumber()),
+	)
+	return nil
+}
+
+// driftIssueBody renders the mismatches as a Markdown table followed by the command fixing them.
+func (a *Action) driftIssueBody(drift []driftEntry) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d floating refs of %s do not point to their expected release.\n\n", len(drift), a.config.GitHubRepo)
+	b.WriteString("| Ref | Status | Actual | Expected | Release |\n")
+	b.WriteString("|-----|--------|--------|----------|---------|\n")
+	for _, d := range drift {
+		actual := "-"
+		if d.ActualSHA != "" {
+			actual = "`" + d.ActualSHA + "`"
+		}
+		fmt.Fprintf(&b, "| %s `%s` | %s | %s | `%s` | `%s` |\n", d.Kind, d.Name, d.Status, actual, d.ExpectedSHA, d.Release)
+	}
+	b.WriteString("\nTo move them to their expected releases, run sync-all with the settings of this check:\n\n")
+	b.WriteString("```bash\n")
+	b.WriteString("semver-tag-sync-action sync-all")
+	for _, arg := range a.fixArgs() {
+		b.WriteString(" " + shellQuote(arg))
+	}
+	b.WriteString("\n```\n")
+	return b.String()
+}
+
+// fixArgs returns the flags of the sync-all command fixing the drift: the repository and the
+// settings the check ran with, including those from the configuration file.
+func (a *Action) fixArgs() []string {
+	args := []string{"--github-repo=" + a.config.GitHubRepo}
+	if a.config.GitHubEnterpriseURL != "" {
+		args = append(args, "--github-enterprise-url="+a.config.GitHubEnterpriseURL)
+	}
+	return append(args, a.config.SyncAllArgs...)
+}
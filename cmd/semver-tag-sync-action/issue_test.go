@@ -0,0 +1,267 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-github/v90/github"
+)
+
+// fakeIssueAPI is a stand-in for the GitHub API serving tags, refs and issues of owner/repo.
+type fakeIssueAPI struct {
+	mu       sync.Mutex
+	tags     []*github.RepositoryTag
+	refs     map[string]string
+	issues   map[int]*github.Issue
+	comments map[int][]string
+	updates  int
+}
+
+func newFakeIssueAPI(t *testing.T) (*fakeIssueAPI, GitHubClient) {
+	t.Helper()
+	api := &fakeIssueAPI{
+		refs:     make(map[string]string),
+		issues:   make(map[int]*github.Issue),
+		comments: make(map[int][]string),
+	}
+	const prefix = "/api/v3/repos/owner/repo"
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+prefix+"/tags", func(w http.ResponseWriter, r *http.Request) {
+		api.write(w, http.StatusOK, api.tags)
+	})
+	mux.HandleFunc("GET "+prefix+"/git/ref/{ref...}", func(w http.ResponseWriter, r *http.Request) {
+		api.mu.Lock()
+		sha, ok := api.refs[r.PathValue("ref")]
+		api.mu.Unlock()
+		if !ok {
+			api.write(w, http.StatusNotFound, map[string]string{"message": "Not Found"})
+			return
+		}
+		api.write(w, http.StatusOK, &github.Reference{Object: &github.GitObject{SHA: github.Ptr(sha)}})
+	})
+	mux.HandleFunc("GET "+prefix+"/issues", func(w http.ResponseWriter, r *http.Request) {
+		api.mu.Lock()
+		defer api.mu.Unlock()
+		issues := []*github.Issue{}
+		for _, issue := range api.issues {
+			if issue.GetState() == r.URL.Query().Get("state") && hasLabel(issue, r.URL.Query().Get("labels")) {
+				issues = append(issues, issue)
+			}
+		}
+		api.write(w, http.StatusOK, issues)
+	})
+	mux.HandleFunc("POST "+prefix+"/issues", func(w http.ResponseWriter, r *http.Request) {
+		var req github.CreateIssueRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding issue: %v", err)
+		}
+		api.mu.Lock()
+		defer api.mu.Unlock()
+		issue := &github.Issue{Number: github.Ptr(len(api.issues) + 1), State: github.Ptr("open"), Title: github.Ptr(req.Title), Body: req.Body}
+		for _, label := range req.Labels {
+			issue.Labels = append(issue.Labels, &github.Label{Name: label})
+		}
+		api.issues[issue.GetNumber()] = issue
+		api.write(w, http.StatusCreated, issue)
+	})
+	mux.HandleFunc("PATCH "+prefix+"/issues/{number}", func(w http.ResponseWriter, r *http.Request) {
+		var req github.UpdateIssueRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding issue update: %v", err)
+		}
+		api.mu.Lock()
+		defer api.mu.Unlock()
+		number, _ := strconv.Atoi(r.PathValue("number"))
+		issue := api.issues[number]
+		if req.Body != nil {
+			issue.Body = req.Body
+		}
+		if req.State != nil {
+			issue.State = req.State
+		}
+		api.updates++
+		api.write(w, http.StatusOK, issue)
+	})
+	mux.HandleFunc("POST "+prefix+"/issues/{number}/comments", func(w http.ResponseWriter, r *http.Request) {
+		var comment github.IssueComment
+		if err := json.NewDecoder(r.Body).Decode(&comment); err != nil {
+			t.Errorf("decoding comment: %v", err)
+		}
+		api.mu.Lock()
+		defer api.mu.Unlock()
+		number, _ := strconv.Atoi(r.PathValue("number"))
+		api.comments[number] = append(api.comments[number], comment.GetBody())
+		api.write(w, http.StatusCreated, &comment)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotImplemented)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	client, err := NewGitHubClient("token", server.URL)
+	if err != nil {
+		t.Fatalf("NewGitHubClient() error = %v", err)
+	}
+	return api, client
+}
+
+func (api *fakeIssueAPI) write(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func hasLabel(issue *github.Issue, name string) bool {
+	for _, label := range issue.Labels {
+		if label.GetName() == name {
+			return true
+		}
+	}
+	return false
+}
+
+func TestActionRunVerify_DriftIssue(t *testing.T) {
+	api, client := newFakeIssueAPI(t)
+	api.tags = []*github.RepositoryTag{
+		makeTag("v1.0.0", "sha100"),
+		makeTag("v1.1.0", "sha110"),
+		makeTag("v2.0.0", "sha200"),
+	}
+	api.refs = map[string]string{"tags/v1": "sha100"}
+
+	// A labeled issue closed earlier must not be reused.
+	api.issues[1] = &github.Issue{
+		Number: github.Ptr(1),
+		State:  github.Ptr("closed"),
+		Labels: []*github.Label{{Name: defaultDriftIssueLabel}},
+	}
+
+	config := Config{
+		GitHubRepo:      "owner/repo",
+		Verify:          true,
+		DriftIssue:      true,
+		SyncMajor:       true,
+		SkipPrereleases: true,
+		SyncAllArgs:     []string{"--version-constraint=>=1 <3"},
+	}
+	runs := 0
+	run := func() error {
+		// Every run has a different URL, which must not make the issue differ.
+		runs++
+		config.RunURL = fmt.Sprintf("https://github.com/owner/repo/actions/runs/%d", runs)
+		action := NewAction(client, config, nil)
+		action.out = &strings.Builder{}
+		return action.Run(context.Background())
+	}
+
+	// Drift opens an issue with a table of the mismatches and the fix command.
+	if err := run(); !errors.Is(err, errDrift) {
+		t.Fatalf("Run() error = %v, want %v", err, errDrift)
+	}
+	issue := api.issues[2]
+	if issue == nil || issue.GetState() != "open" || issue.GetTitle() != driftIssueTitle {
+		t.Fatalf("issues = %v, want a new open drift issue", api.issues)
+	}
+	for _, want := range []string{
+		"| tag `v1` | stale | `sha100` | `sha110` | `v1.1.0` |",
+		"| tag `v2` | missing | - | `sha200` | `v2.0.0` |",
+		"semver-tag-sync-action sync-all --github-repo=owner/repo '--version-constraint=>=1 <3'",
+	} {
+		if !strings.Contains(issue.GetBody(), want) {
+			t.Errorf("issue body = %q, want it to contain %q", issue.GetBody(), want)
+		}
+	}
+
+	if want := []string{"Detected by https://github.com/owner/repo/actions/runs/1."}; !slices.Equal(api.comments[2], want) {
+		t.Errorf("comments = %q, want %q", api.comments[2], want)
+	}
+
+	// The same drift leaves the issue untouched.
+	if err := run(); !errors.Is(err, errDrift) {
+		t.Fatalf("Run() error = %v, want %v", err, errDrift)
+	}
+	if api.updates != 0 || len(api.issues) != 2 || len(api.comments[2]) != 1 {
+		t.Errorf("issue updates = %d, issues = %d, comments = %d, want the issue left untouched", api.updates, len(api.issues), len(api.comments[2]))
+	}
+
+	// Different drift updates the existing issue.
+	api.refs["tags/v2"] = "sha200"
+	if err := run(); !errors.Is(err, errDrift) {
+		t.Fatalf("Run() error = %v, want %v", err, errDrift)
+	}
+	if api.updates != 1 || len(api.issues) != 2 || strings.Contains(issue.GetBody(), "`v2`") {
+		t.Errorf("issue updates = %d, issues = %d, body = %q, want the issue updated", api.updates, len(api.issues), issue.GetBody())
+	}
+
+	// No drift comments on and closes the issue.
+	api.refs["tags/v1"] = "sha110"
+	if err := run(); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	want := []string{
+		"Detected by https://github.com/owner/repo/actions/runs/1.",
+		"Drift changed, detected by https://github.com/owner/repo/actions/runs/3.",
+		"All floating refs are in sync again. Verified by https://github.com/owner/repo/actions/runs/4.",
+	}
+	if issue.GetState() != "closed" || !slices.Equal(api.comments[2], want) {
+		t.Errorf("issue state = %s, comments = %q, want it closed with comments %q", issue.GetState(), api.comments[2], want)
+	}
+
+	// Later runs without drift leave the closed issue alone.
+	if err := run(); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if api.updates != 2 {
+		t.Errorf("issue updates = %d, want 2", api.updates)
+	}
+}
+
+func TestActionRunVerify_DriftIssueSkipsPullRequests(t *testing.T) {
+	var created []string
+	mock := verifyMock(t, []*github.RepositoryTag{makeTag("v1.0.0", "sha100")}, nil)
+	mock.listIssuesFunc = func(ctx context.Context, owner, repo string, opts *github.IssueListByRepoOptions) ([]*github.Issue, *github.Response, error) {
+		if len(opts.Labels) != 1 || opts.Labels[0] != "drift" || opts.State != "open" {
+			t.Errorf("ListIssues() options = %+v, want open issues labeled drift", opts)
+		}
+		return []*github.Issue{{
+			Number:           github.Ptr(7),
+			PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://example.com/pull/7")},
+		}}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+	}
+	mock.createIssueFunc = func(ctx context.Context, owner, repo string, issue github.CreateIssueRequest) (*github.Issue, *github.Response, error) {
+		created = append(created, issue.Labels...)
+		return &github.Issue{Number: github.Ptr(8)}, &github.Response{Response: &http.Response{StatusCode: http.StatusCreated}}, nil
+	}
+	mock.updateIssueFunc = func(ctx context.Context, owner, repo string, number int, issue github.UpdateIssueRequest) (*github.Issue, *github.Response, error) {
+		t.Errorf("UpdateIssue(%d) called, want the pull request ignored", number)
+		return nil, nil, errors.New("unexpected update")
+	}
+
+	config := Config{
+		GitHubRepo:      "owner/repo",
+		Verify:          true,
+		DriftIssue:      true,
+		DriftIssueLabel: "drift",
+		SyncMajor:       true,
+		SkipPrereleases: true,
+	}
+	action := NewAction(mock, config, nil)
+	action.out = &strings.Builder{}
+	if err := action.Run(context.Background()); !errors.Is(err, errDrift) {
+		t.Fatalf("Run() error = %v, want %v", err, errDrift)
+	}
+	if len(created) != 1 || created[0] != "drift" {
+		t.Errorf("created issue labels = %v, want [drift]", created)
+	}
+}
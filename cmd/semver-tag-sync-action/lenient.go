@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// lenientRegex matches loosely formatted version tags like V1.2.3, 1.2.3 or v1.2.
+var lenientRegex = regexp.MustCompile(`^([vV]?)(\d+)\.(\d+)(?:\.(\d+))?([-+].*)?$`)
+
+// ParseLenientSemVer parses a loosely formatted version tag. The version is normalized for
+// grouping: its prefix becomes the given canonical prefix, a missing patch version becomes 0
+// and leading zeros are dropped. Full keeps the tag as written.
+func ParseLenientSemVer(tag, prefix string) (*SemVer, error) {
+	matches := lenientRegex.FindStringSubmatch(tag)
+	if matches == nil {
+		return nil, fmt.Errorf("tag %q does not match semantic versioning format (expected e.g. v1.2.3, V1.2.3, 1.2.3 or v1.2)", tag)
+	}
+	sv := &SemVer{
+		Prefix:       prefix,
+		Major:        trimLeadingZeros(matches[2]),
+		Minor:        trimLeadingZeros(matches[3]),
+		Patch:        trimLeadingZeros(valueOrDefault(matches[4], "0")),
+		Suffix:       matches[5],
+		Full:         tag,
+		IsPrerelease: strings.HasPrefix(matches[5], "-"),
+	}
+	sv.Channel = prereleaseChannel(sv)
+	return sv, nil
+}
+
+// canonicalTag returns the normalized name of a leniently parsed tag, e.g., v1.2.0 for V1.2.
+func canonicalTag(sv *SemVer) string {
+	return fmt.Sprintf("%s%s.%s.%s%s", sv.Prefix, sv.Major, sv.Minor, sv.Patch, sv.Suffix)
+}
+
+// trimLeadingZeros drops leading zeros from a decimal number, keeping a single 0.
+func trimLeadingZeros(number string) string {
+	return valueOrDefault(strings.TrimLeft(number, "0"), "0")
+}
+
+// warnNormalized logs a warning, once per tag, if a leniently parsed tag is not in canonical form.
+func (a *Action) warnNormalized(sv *SemVer) {
+	canonical := canonicalTag(sv)
+	if canonical == sv.Full || a.normalized[sv.Full] {
+		return
+	}
+	if a.normalized == nil {
+		a.normalized = make(map[string]bool)
+	}
+	a.normalized[sv.Full] = true
+	a.log.Warn("Normalized non-canonical release tag",
+		slog.String("tag", sv.Full),
+		slog.String("normalized", canonical),
+	)
+}
+
+// validateLenientMinorTag rejects a minor floating tag named like a two-part release, which lenient
+// parsing accepts: such a release would be moved as soon as a newer patch release of its line ships.
+func validateLenientMinorTag(c *Config, scheme VersionScheme) error {
+	if !c.LenientSemVer || !c.syncMinorAnywhere() || c.MinorRefType == refTypeBranch {
+		return nil
+	}
+	tmpl := valueOrDefault(c.MinorTagTemplate, defaultMinorTagTemplate)
+	name := renderRefTemplate(tmpl, scheme.Sample())
+	if _, err := scheme.Parse(name); err == nil {
+		return fmt.Errorf("--minor-tag-template %q renders %q, which --lenient-semver accepts as a two-part release (set a template that is no version, e.g. {prefix}{major}.{minor}.x, or --minor-ref-type=branch)", tmpl, name)
+	}
+	return nil
+}
+
+// validateCanonicalPrefix checks that floating refs rendered with the canonical prefix are valid ref names.
+func validateCanonicalPrefix(prefix string) error {
+	if err := checkRefFormat(prefix + "1"); err != nil {
+		return fmt.Errorf("invalid --canonical-prefix %q: %w", prefix, err)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v90/github"
+)
+
+func TestParseLenientSemVer(t *testing.T) {
+	tests := []struct {
+		tag           string
+		wantCanonical string
+		wantErr       bool
+	}{
+		{tag: "v1.2.3", wantCanonical: "v1.2.3"},
+		{tag: "V1.2.3", wantCanonical: "v1.2.3"},
+		{tag: "1.2.3-rc.1", wantCanonical: "v1.2.3-rc.1"},
+		{tag: "v1.2", wantCanonical: "v1.2.0"},
+		{tag: "v01.02.03", wantCanonical: "v1.2.3"},
+		{tag: "v1", wantErr: true},
+		{tag: "release-1.2.3", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			sv, err := ParseLenientSemVer(tt.tag, "v")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLenientSemVer(%q) error = %v, wantErr %v", tt.tag, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := canonicalTag(sv); got != tt.wantCanonical {
+				t.Errorf("canonicalTag(%q) = %q, want %q", tt.tag, got, tt.wantCanonical)
+			}
+			if sv.Full != tt.tag {
+				t.Errorf("Full = %q, want %q", sv.Full, tt.tag)
+			}
+		})
+	}
+}
+
+func TestActionRunAll_LenientSemVer(t *testing.T) {
+	var createdRefs []string
+	mock := &mockGitHubClient{
+		listTagsFunc: func(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryTag, *github.Response, error) {
+			return []*github.RepositoryTag{
+				makeTag("V1.2.3", "sha123"),
+				makeTag("1.2.4", "sha124"),
+				makeTag("v1.3", "sha130"),
+				makeTag("v2.0.0", "sha200"),
+			}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+		},
+		createRefFunc: func(ctx context.Context, owner, repo string, ref github.CreateRef) (*github.Reference, *github.Response, error) {
+			createdRefs = append(createdRefs, ref.Ref+"="+ref.SHA)
+			return &github.Reference{}, &github.Response{Response: &http.Response{StatusCode: http.StatusCreated}}, nil
+		},
+	}
+
+	config := Config{
+		GitHubRepo:       "owner/repo",
+		SyncAllTags:      true,
+		SyncMajor:        true,
+		SyncMinor:        true,
+		LenientSemVer:    true,
+		CanonicalPrefix:  "v",
+		MinorTagTemplate: "{prefix}{major}.{minor}.x",
+	}
+
+	var logs bytes.Buffer
+	action := NewAction(mock, config, slog.New(slog.NewTextHandler(&logs, nil)))
+	if err := action.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	want := []string{
+		"refs/tags/v1.2.x=sha124",
+		"refs/tags/v1.3.x=sha130",
+		"refs/tags/v1=sha130",
+		"refs/tags/v2.0.x=sha200",
+		"refs/tags/v2=sha200",
+	}
+	slices.Sort(createdRefs)
+	if !slices.Equal(createdRefs, want) {
+		t.Errorf("created refs = %v, want %v", createdRefs, want)
+	}
+
+	var warnings []string
+	for line := range strings.Lines(logs.String()) {
+		if strings.Contains(line, "Normalized non-canonical release tag") {
+			warnings = append(warnings, line)
+		}
+	}
+	if len(warnings) != 3 {
+		t.Fatalf("expected 3 normalization warnings, got %d: %v", len(warnings), warnings)
+	}
+	if !strings.Contains(warnings[2], "tag=v1.3 normalized=v1.3.0") {
+		t.Errorf("unexpected warning: %s", warnings[2])
+	}
+}
+
+func TestActionRunInspect_LenientSkipsFloatingTags(t *testing.T) {
+	// Validate rejects this configuration, but tags named like the minor floating tags may predate it.
+	mock := verifyMock(t, []*github.RepositoryTag{
+		makeTag("v1.2.0", "sha120"),
+		makeTag("1.2.1", "sha121"),
+		makeTag("v1.2", "sha120"),
+	}, map[string]string{"tags/v1.2": "sha120"})
+	config := Config{
+		GitHubRepo:      "owner/repo",
+		SyncMinor:       true,
+		LenientSemVer:   true,
+		CanonicalPrefix: "v",
+	}
+
+	lines, err := NewAction(mock, config, nil).inspect(context.Background(), "owner", "repo")
+	if err != nil {
+		t.Fatalf("inspect() error = %v", err)
+	}
+	if len(lines) != 1 || lines[0].Latest != "1.2.1" || lines[0].Releases != 2 || lines[0].Status != driftStale {
+		t.Errorf("lines = %+v, want v1.2 stale with the 2 releases v1.2.0 and 1.2.1", lines)
+	}
+}
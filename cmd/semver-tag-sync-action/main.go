@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log/slog"
@@ -20,95 +21,86 @@ var (
 )
 
 func main() {
-	var (
-		githubToken         string
-		githubRepo          string
-		gitRef              string
-		commitSHA           string
-		syncMajor           bool
-		syncMinor           bool
-		skipPrereleases     bool
-		syncAllTags         bool
-		dryRun              bool
-		githubEnterpriseURL string
-		logLevel            string
-		showVersion         bool
-	)
-
-	flag.StringVar(&githubToken, "github-token", "", "GitHub token for authentication (or set GITHUB_TOKEN)")
-	flag.StringVar(&githubRepo, "github-repo", "", "Target repository in owner/repo format (default: GITHUB_REPOSITORY)")
-	flag.StringVar(&gitRef, "git-ref", "", "Git reference, e.g., refs/tags/v1.2.3 (default: GITHUB_REF)")
-	flag.StringVar(&commitSHA, "commit-sha", "", "Commit SHA to point the tags to (default: GITHUB_SHA)")
-	flag.BoolVar(&syncMajor, "sync-major", true, "Sync major version tag (e.g., v1)")
-	flag.BoolVar(&syncMinor, "sync-minor", true, "Sync minor version tag (e.g., v1.2)")
-	flag.BoolVar(&skipPrereleases, "skip-prereleases", true, "Skip syncing for prerelease versions (e.g., v1.2.3-beta)")
-	flag.BoolVar(&syncAllTags, "sync-all-tags", false, "Sync major/minor tags for all existing semver tags in the repository")
-	flag.BoolVar(&dryRun, "dry-run", false, "Perform a dry run without making changes")
-	flag.StringVar(&githubEnterpriseURL, "github-enterprise-url", "", "GitHub Enterprise URL (optional)")
-	flag.StringVar(&logLevel, "log-level", "info", "Log level (debug, info, warn, error)")
-	flag.BoolVar(&showVersion, "version", false, "Show version information")
-
-	flag.Parse()
+	opts := defaultOptions()
+	cmd, flags, err := parseCommandLine(os.Args[1:], opts)
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
 
-	if showVersion {
+	if opts.showVersion {
 		fmt.Printf("semver-tag-sync-action\nVersion: %s %s\nBuildDate: %s\n%s\n", Revision, Version, BuildDate, GoVersion)
 		os.Exit(0)
 	}
 
 	// Setup logger
-	log := setupLogger(logLevel)
+	log := setupLogger(opts.logLevel)
 
 	log.Debug("Starting with configuration",
 		slog.String("version", Version),
 		slog.String("revision", Revision),
 		slog.String("build_date", BuildDate),
 		slog.String("go_version", GoVersion),
-		slog.String("log_level", logLevel),
+		slog.String("log_level", opts.logLevel),
+		slog.String("command", valueOrDefault(cmd.name, "(flags)")),
 	)
 
 	// Auto-discover from GitHub Actions environment if not explicitly set
-	githubToken = getEnvOrDefault(githubToken, "GITHUB_TOKEN")
-	githubRepo = getEnvOrDefault(githubRepo, "GITHUB_REPOSITORY")
-	gitRef = getEnvOrDefault(gitRef, "GITHUB_REF")
-	commitSHA = getEnvOrDefault(commitSHA, "GITHUB_SHA")
+	opts.githubToken = getEnvOrDefault(opts.githubToken, "GITHUB_TOKEN")
+	opts.githubRepo = getEnvOrDefault(opts.githubRepo, "GITHUB_REPOSITORY")
+	opts.gitRef = getEnvOrDefault(opts.gitRef, "GITHUB_REF")
+	opts.commitSHA = getEnvOrDefault(opts.commitSHA, "GITHUB_SHA")
 
-	config := Config{
-		GitHubToken:         githubToken,
-		GitHubRepo:          githubRepo,
-		GitRef:              gitRef,
-		CommitSHA:           commitSHA,
-		SyncMajor:           syncMajor,
-		SyncMinor:           syncMinor,
-		SkipPrereleases:     skipPrereleases,
-		SyncAllTags:         syncAllTags,
-		DryRun:              dryRun,
-		GitHubEnterpriseURL: githubEnterpriseURL,
-		LogLevel:            logLevel,
+	// Create GitHub client
+	client, err := NewGitHubClient(opts.githubToken, opts.githubEnterpriseURL)
+	if err != nil {
+		log.Error("Failed to create GitHub client",
+			slog.String("error", err.Error()),
+		)
+		os.Exit(1)
 	}
 
-	// Validate configuration
-	if err := config.Validate(); err != nil {
+	// Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	// Apply the repository configuration file to flags that are not explicitly set
+	fileRepo := opts.githubRepo
+	if opts.githubToken == "" {
+		fileRepo = ""
+	}
+	applied, origin, err := applyRepositoryConfig(ctx, client, flags, opts.configSource, opts.configFile, fileRepo, opts.commitSHA)
+	if err != nil {
 		log.Error("Configuration validation failed",
 			slog.String("error", err.Error()),
 		)
 		os.Exit(1)
 	}
+	if origin != "" {
+		log.Info("Loaded configuration file",
+			slog.String("file", origin),
+			slog.String("keys", strings.Join(applied, ",")),
+		)
+	}
 
-	// Create GitHub client
-	client, err := NewGitHubClient(config.GitHubToken, config.GitHubEnterpriseURL)
+	// Validate configuration
+	config, err := opts.config()
+	if err == nil {
+		config.SyncAllArgs = syncAllArgs(flags)
+		err = config.Validate()
+	}
 	if err != nil {
-		log.Error("Failed to create GitHub client",
-			slog.String("error", err.Error()),
+		log.Error("Configuration validation failed",
+			slog.String("error", attributeConfigError(err, applied, origin).Error()),
 		)
 		os.Exit(1)
 	}
 
 	action := NewAction(client, config, log)
 
-	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
-	defer cancel()
-
 	if err := action.Run(ctx); err != nil {
 		log.Error("Action failed",
 			slog.String("error", err.Error()),
@@ -117,10 +109,11 @@ func main() {
 	}
 }
 
-// setupLogger creates a new slog.Logger with the specified log level.
+// setupLogger creates a new slog.Logger with the specified log level. It writes to stderr,
+// so reports printed to stdout, such as the JSON of verify and inspect, stay parseable.
 func setupLogger(level string) *slog.Logger {
 	logLevel := stringToLogLevel(level)
-	handler := slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+	handler := slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
 		Level: logLevel,
 	})
 	return slog.New(handler)
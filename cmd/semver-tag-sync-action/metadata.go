@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Policies for choosing between versions that differ only in build metadata, e.g., v1.2.3 and v1.2.3+linux.
+const (
+	buildMetadataPreferPlain = "prefer-plain" // The variant without build metadata wins
+	buildMetadataIgnore      = "ignore"       // Tags with build metadata are not treated as releases
+	buildMetadataNewest      = "newest"       // The variant whose commit is newest wins
+	buildMetadataLexical     = "lexical"      // The lexically greatest tag name wins
+)
+
+// errBuildMetadataIgnored is returned when parsing a tag with build metadata under the ignore policy.
+var errBuildMetadataIgnored = errors.New("tag has build metadata, which is ignored")
+
+// hasBuildMetadata reports whether sv carries build metadata (or a PEP 440 local version label).
+func hasBuildMetadata(sv *SemVer) bool {
+	return strings.Contains(sv.Suffix, "+")
+}
+
+// beats reports whether candidate should replace current as the release of a floating group.
+// Versions of equal precedence are decided by the build metadata policy, falling back to the
+// lexically greatest tag name so that the outcome never depends on the order tags are listed in.
+func (a *Action) beats(ctx context.Context, owner, repo string, candidate, current *tagWithSHA) (bool, error) {
+	if c := a.scheme.Compare(candidate.semver, current.semver); c != 0 {
+		return c > 0, nil
+	}
+
+	switch a.config.BuildMetadata {
+	case buildMetadataLexical:
+	case buildMetadataNewest:
+		candidateDate, err := a.commitDate(ctx, owner, repo, candidate)
+		if err != nil {
+			return false, err
+		}
+		currentDate, err := a.commitDate(ctx, owner, repo, current)
+		if err != nil {
+			return false, err
+		}
+		if !candidateDate.Equal(currentDate) {
+			return candidateDate.After(currentDate), nil
+		}
+	default:
+		if plain := !hasBuildMetadata(candidate.semver); plain != !hasBuildMetadata(current.semver) {
+			return plain, nil
+		}
+	}
+	return candidate.semver.Full > current.semver.Full, nil
+}
+
+// validateBuildMetadata checks that a build metadata policy option holds a supported value.
+func validateBuildMetadata(value string) error {
+	switch value {
+	case "", buildMetadataPreferPlain, buildMetadataIgnore, buildMetadataNewest, buildMetadataLexical:
+		return nil
+	default:
+		return fmt.Errorf("invalid --build-metadata %q (expected %s, %s, %s or %s)",
+			value, buildMetadataPreferPlain, buildMetadataIgnore, buildMetadataNewest, buildMetadataLexical)
+	}
+}
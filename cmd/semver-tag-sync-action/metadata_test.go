@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v90/github"
+)
+
+func TestActionRunAll_BuildMetadataPolicy(t *testing.T) {
+	tags := []*github.RepositoryTag{
+		makeTag("v1.2.3+linux", "shalinux"),
+		makeTag("v1.2.3", "shaplain"),
+		makeTag("v1.2.3+darwin", "shadarwin"),
+	}
+	dates := map[string]time.Time{
+		"shalinux":  time.Date(2026, 10, 3, 0, 0, 0, 0, time.UTC),
+		"shaplain":  time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		"shadarwin": time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name   string
+		policy string
+		tags   []*github.RepositoryTag
+		want   string
+	}{
+		{name: "default prefers plain", policy: "", want: "shaplain"},
+		{name: "prefer plain", policy: buildMetadataPreferPlain, want: "shaplain"},
+		{name: "newest commit", policy: buildMetadataNewest, want: "shalinux"},
+		{name: "lexical", policy: buildMetadataLexical, want: "shalinux"},
+		{name: "ignore", policy: buildMetadataIgnore, want: "shaplain"},
+		{
+			name:   "prefer plain without plain variant falls back to lexical",
+			policy: buildMetadataPreferPlain,
+			tags:   []*github.RepositoryTag{makeTag("v1.2.3+linux", "shalinux"), makeTag("v1.2.3+darwin", "shadarwin")},
+			want:   "shalinux",
+		},
+		{
+			name:   "ignore only variants",
+			policy: buildMetadataIgnore,
+			tags:   []*github.RepositoryTag{makeTag("v1.2.3+linux", "shalinux")},
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidates := tt.tags
+			if candidates == nil {
+				candidates = tags
+			}
+			// The winner must not depend on the order the tags are listed in.
+			reversed := slices.Clone(candidates)
+			slices.Reverse(reversed)
+			for _, order := range [][]*github.RepositoryTag{candidates, reversed} {
+				var createdRefs []string
+				mock := &mockGitHubClient{
+					listTagsFunc: func(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryTag, *github.Response, error) {
+						return order, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+					},
+					getCommitFunc: func(ctx context.Context, owner, repo, sha string) (*github.Commit, *github.Response, error) {
+						date := dates[sha]
+						return &github.Commit{
+							Committer: &github.CommitAuthor{Date: &github.Timestamp{Time: date}},
+						}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+					},
+					createRefFunc: func(ctx context.Context, owner, repo string, ref github.CreateRef) (*github.Reference, *github.Response, error) {
+						createdRefs = append(createdRefs, ref.SHA)
+						return &github.Reference{}, &github.Response{Response: &http.Response{StatusCode: http.StatusCreated}}, nil
+					},
+				}
+
+				config := Config{
+					GitHubRepo:    "owner/repo",
+					SyncAllTags:   true,
+					SyncMajor:     true,
+					BuildMetadata: tt.policy,
+				}
+
+				action := NewAction(mock, config, nil)
+				if err := action.Run(context.Background()); err != nil {
+					t.Fatalf("Run() error = %v", err)
+				}
+
+				var want []string
+				if tt.want != "" {
+					want = []string{tt.want}
+				}
+				if !slices.Equal(createdRefs, want) {
+					t.Errorf("v1 synced to %v, want %v", createdRefs, want)
+				}
+			}
+		})
+	}
+}
+
+func TestActionRun_BuildMetadataIgnored(t *testing.T) {
+	mock := &mockGitHubClient{
+		createRefFunc: func(ctx context.Context, owner, repo string, ref github.CreateRef) (*github.Reference, *github.Response, error) {
+			t.Errorf("unexpected ref creation: %s", ref.Ref)
+			return &github.Reference{}, &github.Response{Response: &http.Response{StatusCode: http.StatusCreated}}, nil
+		},
+	}
+
+	config := Config{
+		GitHubRepo:    "owner/repo",
+		GitRef:        "refs/tags/v1.2.3+linux",
+		CommitSHA:     "abc123",
+		SyncMajor:     true,
+		BuildMetadata: buildMetadataIgnore,
+	}
+
+	action := NewAction(mock, config, nil)
+	if err := action.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// override changes the sync settings for the releases matching its selector:
+// a version range on the major version or a tag name prefix. Unset settings are inherited.
+type override struct {
+	selector        string
+	major           versionConstraint
+	prefix          string
+	syncMajor       *bool
+	syncMinor       *bool
+	skipPrereleases *bool
+}
+
+// groupSettings are the sync settings that apply to the groups of a release.
+type groupSettings struct {
+	syncMajor       bool
+	syncMinor       bool
+	skipPrereleases bool
+}
+
+// parseOverrides parses override rules separated by newlines or semicolons, such as
+// "major 1: sync-major=false, sync-minor=false" or "prefix v2.: skip-prereleases=false". A prefix
+// is matched against the full release tag name, including its "v".
+func parseOverrides(value string) ([]override, error) {
+	var overrides []override
+	for rule := range strings.FieldsFuncSeq(value, func(r rune) bool { return r == '\n' || r == ';' }) {
+		if rule = strings.TrimSpace(rule); rule == "" {
+			continue
+		}
+		o, err := parseOverride(rule)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --overrides rule %q: %w", rule, err)
+		}
+		overrides = append(overrides, o)
+	}
+	return overrides, nil
+}
+
+// parseOverride parses a single "selector: key=value, ..." rule.
+func parseOverride(rule string) (override, error) {
+	selector, settings, ok := strings.Cut(rule, ":")
+	if !ok {
+		return override{}, fmt.Errorf("expected selector: settings")
+	}
+	o := override{selector: strings.TrimSpace(selector)}
+	kind, arg, _ := strings.Cut(o.selector, " ")
+	arg = strings.TrimSpace(arg)
+	switch {
+	case kind == "major" && arg != "":
+		major, err := parseVersionConstraint(arg)
+		if err != nil {
+			return override{}, err
+		}
+		o.major = major
+	case kind == "prefix" && arg != "":
+		o.prefix = arg
+	default:
+		return override{}, fmt.Errorf("selector must be major <range> or prefix <prefix>")
+	}
+
+	for _, setting := range splitList(settings) {
+		key, raw, _ := strings.Cut(setting, "=")
+		value, err := strconv.ParseBool(strings.TrimSpace(raw))
+		if err != nil {
+			return override{}, fmt.Errorf("setting %q must be key=true or key=false", setting)
+		}
+		switch strings.TrimSpace(key) {
+		case "sync-major":
+			o.syncMajor = &value
+		case "sync-minor":
+			o.syncMinor = &value
+		case "skip-prereleases":
+			o.skipPrereleases = &value
+		default:
+			return override{}, fmt.Errorf("unknown setting %q (expected sync-major, sync-minor or skip-prereleases)", key)
+		}
+	}
+	if o.syncMajor == nil && o.syncMinor == nil && o.skipPrereleases == nil {
+		return override{}, fmt.Errorf("no settings")
+	}
+	return o, nil
+}
+
+// matches reports whether the override applies to sv.
+func (o override) matches(sv *SemVer) bool {
+	if o.prefix != "" {
+		return strings.HasPrefix(sv.Full, o.prefix)
+	}
+	return o.major.match(sv)
+}
+
+// settings returns the sync settings for sv: the global configuration with every matching
+// override applied in order, so later rules win.
+func (a *Action) settings(sv *SemVer) groupSettings {
+	s := groupSettings{
+		syncMajor:       a.config.SyncMajor,
+		syncMinor:       a.config.SyncMinor,
+		skipPrereleases: a.config.SkipPrereleases,
+	}
+	for _, o := range a.config.Overrides {
+		if !o.matches(sv) {
+			continue
+		}
+		if o.syncMajor != nil {
+			s.syncMajor = *o.syncMajor
+		}
+		if o.syncMinor != nil {
+			s.syncMinor = *o.syncMinor
+		}
+		if o.skipPrereleases != nil {
+			s.skipPrereleases = *o.skipPrereleases
+		}
+	}
+	return s
+}
+
+// syncMajorAnywhere reports whether the major level is enabled globally or by an override.
+func (c *Config) syncMajorAnywhere() bool {
+	return c.SyncMajor || c.enabledByOverride(func(o override) *bool { return o.syncMajor })
+}
+
+// syncMinorAnywhere reports whether the minor level is enabled globally or by an override.
+func (c *Config) syncMinorAnywhere() bool {
+	return c.SyncMinor || c.enabledByOverride(func(o override) *bool { return o.syncMinor })
+}
+
+// enabledByOverride reports whether any override sets the given setting to true.
+func (c *Config) enabledByOverride(setting func(o override) *bool) bool {
+	for _, o := range c.Overrides {
+		if v := setting(o); v != nil && *v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/google/go-github/v90/github"
+)
+
+func TestParseOverrides(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    int
+		wantErr bool
+	}{
+		{name: "empty", value: ""},
+		{name: "semicolons", value: "major 0: sync-major=false; major >=2: sync-major=true, sync-minor=true", want: 2},
+		{name: "newlines", value: "major 1: sync-major=false, sync-minor=false\nprefix v2.: skip-prereleases=false\n", want: 2},
+		{name: "missing settings", value: "major 1", wantErr: true},
+		{name: "unknown selector", value: "minor 1: sync-major=false", wantErr: true},
+		{name: "invalid range", value: "major >=two: sync-major=false", wantErr: true},
+		{name: "unknown setting", value: "major 1: sync-patch=false", wantErr: true},
+		{name: "invalid value", value: "major 1: sync-major=maybe", wantErr: true},
+		{name: "empty settings", value: "major 1:", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseOverrides(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseOverrides(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if len(got) != tt.want {
+				t.Errorf("parseOverrides(%q) returned %d rules, want %d", tt.value, len(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestActionRun_Overrides(t *testing.T) {
+	overrides, err := parseOverrides("major 0: sync-minor=true; major >=2: sync-major=true, sync-minor=true")
+	if err != nil {
+		t.Fatalf("parseOverrides() error = %v", err)
+	}
+
+	tests := []struct {
+		ref  string
+		want []string
+	}{
+		{ref: "refs/tags/v0.3.1", want: []string{"refs/tags/v0.3"}},
+		{ref: "refs/tags/v1.4.0"},
+		{ref: "refs/tags/v2.1.0", want: []string{"refs/tags/v2", "refs/tags/v2.1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			var createdRefs []string
+			mock := &mockGitHubClient{
+				createRefFunc: func(ctx context.Context, owner, repo string, ref github.CreateRef) (*github.Reference, *github.Response, error) {
+					createdRefs = append(createdRefs, ref.Ref)
+					return &github.Reference{}, &github.Response{Response: &http.Response{StatusCode: http.StatusCreated}}, nil
+				},
+			}
+
+			config := Config{
+				GitHubRepo: "owner/repo",
+				GitRef:     tt.ref,
+				CommitSHA:  "abc123",
+				Overrides:  overrides,
+			}
+
+			action := NewAction(mock, config, nil)
+			if err := action.Run(context.Background()); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if !slices.Equal(createdRefs, tt.want) {
+				t.Errorf("created refs = %v, want %v", createdRefs, tt.want)
+			}
+		})
+	}
+}
+
+func TestActionRunAll_Overrides(t *testing.T) {
+	var createdRefs []string
+	mock := &mockGitHubClient{
+		listTagsFunc: func(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryTag, *github.Response, error) {
+			return []*github.RepositoryTag{
+				makeTag("v0.3.1", "sha031"),
+				makeTag("v1.4.0", "sha140"),
+				makeTag("v2.1.0", "sha210"),
+				makeTag("v2.2.0-rc.1", "sha220rc1"),
+			}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+		},
+		createRefFunc: func(ctx context.Context, owner, repo string, ref github.CreateRef) (*github.Reference, *github.Response, error) {
+			createdRefs = append(createdRefs, ref.Ref+"="+ref.SHA)
+			return &github.Reference{}, &github.Response{Response: &http.Response{StatusCode: http.StatusCreated}}, nil
+		},
+	}
+
+	overrides, err := parseOverrides("major 1: sync-major=false, sync-minor=false\nmajor 2: skip-prereleases=false")
+	if err != nil {
+		t.Fatalf("parseOverrides() error = %v", err)
+	}
+	config := Config{
+		GitHubRepo:      "owner/repo",
+		SyncAllTags:     true,
+		SyncMajor:       true,
+		SyncMinor:       true,
+		SkipPrereleases: true,
+		Overrides:       overrides,
+	}
+
+	action := NewAction(mock, config, nil)
+	if err := action.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	slices.Sort(createdRefs)
+	want := []string{
+		"refs/tags/v0.3=sha031",
+		"refs/tags/v0=sha031",
+		"refs/tags/v2.1=sha210",
+		"refs/tags/v2.2=sha220rc1",
+		"refs/tags/v2=sha220rc1",
+	}
+	if !slices.Equal(createdRefs, want) {
+		t.Errorf("created refs = %v, want %v", createdRefs, want)
+	}
+}
+
+func TestActionRunAll_PrefixOverride(t *testing.T) {
+	var createdRefs []string
+	mock := &mockGitHubClient{
+		listTagsFunc: func(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryTag, *github.Response, error) {
+			return []*github.RepositoryTag{
+				makeTag("v1.4.0", "sha140"),
+				makeTag("v1.5.0-rc.1", "sha150rc1"),
+				makeTag("v2.1.0", "sha210"),
+				makeTag("v2.2.0-rc.1", "sha220rc1"),
+			}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+		},
+		createRefFunc: func(ctx context.Context, owner, repo string, ref github.CreateRef) (*github.Reference, *github.Response, error) {
+			createdRefs = append(createdRefs, ref.Ref+"="+ref.SHA)
+			return &github.Reference{}, &github.Response{Response: &http.Response{StatusCode: http.StatusCreated}}, nil
+		},
+	}
+
+	overrides, err := parseOverrides("prefix v2.: skip-prereleases=false")
+	if err != nil {
+		t.Fatalf("parseOverrides() error = %v", err)
+	}
+	config := Config{
+		GitHubRepo:      "owner/repo",
+		SyncAllTags:     true,
+		SyncMajor:       true,
+		SkipPrereleases: true,
+		Overrides:       overrides,
+	}
+
+	action := NewAction(mock, config, nil)
+	if err := action.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	// Only the v2 releases match the prefix, so only v2 takes its prerelease.
+	slices.Sort(createdRefs)
+	want := []string{
+		"refs/tags/v1=sha140",
+		"refs/tags/v2=sha220rc1",
+	}
+	if !slices.Equal(createdRefs, want) {
+		t.Errorf("created refs = %v, want %v", createdRefs, want)
+	}
+}
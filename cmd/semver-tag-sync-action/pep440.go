@@ -0,0 +1,254 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// pep440Regex matches PEP 440 versions like 1.4.0, v1.4.0rc1, 1.4.0.post2 or 1!2.0.dev3+local.
+// See https://peps.python.org/pep-0440/#appendix-b-parsing-version-strings-with-regular-expressions.
+var pep440Regex = regexp.MustCompile(`(?i)^(v)?(?:(\d+)!)?(\d+(?:\.\d+)*)` +
+	`(?:[-_.]?(a|b|c|rc|alpha|beta|pre|preview)[-_.]?(\d+)?)?` +
+	`(?:-(\d+)|[-_.]?(post|rev|r)[-_.]?(\d+)?)?` +
+	`(?:[-_.]?(dev)[-_.]?(\d+)?)?` +
+	`(?:\+([a-z0-9]+(?:[-_.][a-z0-9]+)*))?$`)
+
+// pep440FloatingRegex matches v-prefixed one- and two-segment versions like v1 or v1.4, which are
+// the names of floating tags rather than releases.
+var pep440FloatingRegex = regexp.MustCompile(`(?i)^v\d+(?:\.\d+)?$`)
+
+// pep440Phases maps the spellings of prerelease phases to their normalized form.
+var pep440Phases = map[string]string{
+	"a": "a", "alpha": "a",
+	"b": "b", "beta": "b",
+	"c": "rc", "rc": "rc", "pre": "rc", "preview": "rc",
+}
+
+// pep440Version holds the components of a PEP 440 version that determine its ordering.
+type pep440Version struct {
+	epoch   int
+	release []int
+	phase   string // Normalized prerelease phase ("a", "b" or "rc"), empty if none
+	pre     int
+	hasPost bool
+	post    int
+	hasDev  bool
+	dev     int
+	local   string
+}
+
+// pep440Scheme implements VersionScheme for Python package versions as specified by PEP 440.
+type pep440Scheme struct{}
+
+// Parse parses a PEP 440 version tag. Release segments are normalized, so 1.04 has minor version 4.
+// Floating tag names like v1 or v1.4 are rejected although PEP 440 would accept them.
+func (pep440Scheme) Parse(tag string) (*SemVer, error) {
+	if pep440FloatingRegex.MatchString(tag) {
+		return nil, fmt.Errorf("tag %q is a floating tag name, not a release (expected e.g. v1.4.0)", tag)
+	}
+	v, end, err := parsePEP440(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := ""
+	if tag[0] == 'v' || tag[0] == 'V' {
+		prefix = tag[:1]
+	}
+	sv := &SemVer{
+		Prefix:       prefix,
+		Major:        strconv.Itoa(v.release[0]),
+		Suffix:       tag[end:],
+		Full:         tag,
+		IsPrerelease: v.phase != "" || v.hasDev,
+	}
+	if len(v.release) > 1 {
+		sv.Minor = strconv.Itoa(v.release[1])
+	}
+	if len(v.release) > 2 {
+		sv.Patch = strconv.Itoa(v.release[2])
+	}
+	switch {
+	case v.phase != "":
+		sv.Channel = v.phase
+	case v.hasDev:
+		sv.Channel = "dev"
+	}
+	return sv, nil
+}
+
+// Compare orders two versions as specified by PEP 440.
+func (pep440Scheme) Compare(a, b *SemVer) int {
+	// Both versions were produced by Parse, so they always parse again.
+	va, _, _ := parsePEP440(a.Full)
+	vb, _, _ := parsePEP440(b.Full)
+	return comparePEP440(va, vb)
+}
+
+// MajorKey returns the major version line of sv, qualified by its epoch if it has one.
+func (s pep440Scheme) MajorKey(sv *SemVer) string {
+	return s.epochPrefix(sv) + "v" + sv.Major
+}
+
+// MinorKey returns the minor version line of sv, qualified by its epoch if it has one.
+func (s pep440Scheme) MinorKey(sv *SemVer) string {
+	return s.epochPrefix(sv) + "v" + sv.Major + "." + sv.Minor
+}
+
+// Sample returns the release v1.2.3.
+func (s pep440Scheme) Sample() *SemVer {
+	sv, _ := s.Parse("v1.2.3")
+	return sv
+}
+
+// epochPrefix returns "N!" for versions with a non-zero epoch, keeping their lines apart.
+func (pep440Scheme) epochPrefix(sv *SemVer) string {
+	v, _, err := parsePEP440(sv.Full)
+	if err != nil || v.epoch == 0 {
+		return ""
+	}
+	return strconv.Itoa(v.epoch) + "!"
+}
+
+// parsePEP440 parses a PEP 440 version and returns it with the offset at which its release segments end.
+func parsePEP440(tag string) (*pep440Version, int, error) {
+	m := pep440Regex.FindStringSubmatchIndex(tag)
+	if m == nil {
+		return nil, 0, fmt.Errorf("tag %q does not match PEP 440 versioning format (expected e.g. 1.4.0, 1.4.0rc1 or 1.4.0.post2)", tag)
+	}
+	group := func(i int) string {
+		if m[2*i] < 0 {
+			return ""
+		}
+		return tag[m[2*i]:m[2*i+1]]
+	}
+	number := func(s string) int {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			// Absent numbers default to 0; overlong ones saturate to keep their order.
+			if s == "" {
+				return 0
+			}
+			return int(^uint(0) >> 1)
+		}
+		return n
+	}
+
+	v := &pep440Version{epoch: number(group(2))}
+	for segment := range strings.SplitSeq(group(3), ".") {
+		v.release = append(v.release, number(segment))
+	}
+	if phase := group(4); phase != "" {
+		v.phase = pep440Phases[strings.ToLower(phase)]
+		v.pre = number(group(5))
+	}
+	if implicit := group(6); implicit != "" {
+		v.hasPost, v.post = true, number(implicit)
+	} else if group(7) != "" {
+		v.hasPost, v.post = true, number(group(8))
+	}
+	if group(9) != "" {
+		v.hasDev, v.dev = true, number(group(10))
+	}
+	v.local = strings.ToLower(group(11))
+	return v, m[7], nil
+}
+
+// comparePEP440 compares two PEP 440 versions: epoch, release (ignoring trailing zeros),
+// prerelease, post-release, development release and finally the local version label.
+func comparePEP440(a, b *pep440Version) int {
+	if c := cmpInt(a.epoch, b.epoch); c != 0 {
+		return c
+	}
+	for i := 0; i < len(a.release) || i < len(b.release); i++ {
+		var x, y int
+		if i < len(a.release) {
+			x = a.release[i]
+		}
+		if i < len(b.release) {
+			y = b.release[i]
+		}
+		if c := cmpInt(x, y); c != 0 {
+			return c
+		}
+	}
+	if c := cmpInt(a.preRank(), b.preRank()); c != 0 {
+		return c
+	}
+	if a.phase != "" && b.phase != "" {
+		if c := cmpInt(a.pre, b.pre); c != 0 {
+			return c
+		}
+	}
+	if c := cmpInt(optionalRank(a.hasPost, a.post, -1), optionalRank(b.hasPost, b.post, -1)); c != 0 {
+		return c
+	}
+	if c := cmpInt(optionalRank(a.hasDev, a.dev, int(^uint(0)>>1)), optionalRank(b.hasDev, b.dev, int(^uint(0)>>1))); c != 0 {
+		return c
+	}
+	switch {
+	case a.local == b.local:
+		return 0
+	case a.local == "":
+		return -1
+	case b.local == "":
+		return 1
+	}
+	return compareLocal(localSegments(a.local), localSegments(b.local))
+}
+
+// localSegments splits a local version label on the separators PEP 440 normalizes to dots.
+func localSegments(local string) []string {
+	return strings.FieldsFunc(local, func(r rune) bool { return r == '.' || r == '-' || r == '_' })
+}
+
+// compareLocal compares local version label segments: numeric segments compare numerically
+// and rank above alphanumeric ones, which compare lexically, and a shorter label ranks below
+// a longer one with the same prefix.
+func compareLocal(a, b []string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		aNum, bNum := isNumeric(a[i]), isNumeric(b[i])
+		switch {
+		case aNum && bNum:
+			if c := compareNumeric(a[i], b[i]); c != 0 {
+				return c
+			}
+		case aNum:
+			return 1
+		case bNum:
+			return -1
+		default:
+			if c := strings.Compare(a[i], b[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return cmpInt(len(a), len(b))
+}
+
+// preRank ranks the prerelease phase: a development release of a final version sorts before
+// its alpha, beta and release candidates, which all sort before the final release.
+func (v *pep440Version) preRank() int {
+	switch v.phase {
+	case "a":
+		return 1
+	case "b":
+		return 2
+	case "rc":
+		return 3
+	}
+	if v.hasDev && !v.hasPost {
+		return 0
+	}
+	return 4
+}
+
+// optionalRank returns n if the component is present and absent otherwise.
+func optionalRank(present bool, n, absent int) int {
+	if present {
+		return n
+	}
+	return absent
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/google/go-github/v90/github"
+)
+
+func TestPEP440Parse(t *testing.T) {
+	tests := []struct {
+		tag            string
+		wantMajor      string
+		wantMinor      string
+		wantPatch      string
+		wantPrerelease bool
+		wantChannel    string
+		wantErr        bool
+	}{
+		{tag: "1.4.0", wantMajor: "1", wantMinor: "4", wantPatch: "0"},
+		{tag: "v1.4.0", wantMajor: "1", wantMinor: "4", wantPatch: "0"},
+		{tag: "1.4.0rc1", wantMajor: "1", wantMinor: "4", wantPatch: "0", wantPrerelease: true, wantChannel: "rc"},
+		{tag: "1.4.0-beta.2", wantMajor: "1", wantMinor: "4", wantPatch: "0", wantPrerelease: true, wantChannel: "b"},
+		{tag: "1.4.0.dev3", wantMajor: "1", wantMinor: "4", wantPatch: "0", wantPrerelease: true, wantChannel: "dev"},
+		{tag: "1.4.0.post2", wantMajor: "1", wantMinor: "4", wantPatch: "0"},
+		{tag: "1.4.0-1", wantMajor: "1", wantMinor: "4", wantPatch: "0"},
+		{tag: "1.04", wantMajor: "1", wantMinor: "4"},
+		{tag: "1.4", wantMajor: "1", wantMinor: "4"},
+		{tag: "v1", wantErr: true},
+		{tag: "V1.4", wantErr: true},
+		{tag: "1!2.0.0+ubuntu.1", wantMajor: "2", wantMinor: "0", wantPatch: "0"},
+		{tag: "1.4.0-rc~1", wantErr: true},
+		{tag: "latest", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			sv, err := pep440Scheme{}.Parse(tt.tag)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse(%q) error = %v, wantErr %v", tt.tag, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if sv.Major != tt.wantMajor || sv.Minor != tt.wantMinor || sv.Patch != tt.wantPatch {
+				t.Errorf("Parse(%q) = %s/%s/%s, want %s/%s/%s", tt.tag, sv.Major, sv.Minor, sv.Patch, tt.wantMajor, tt.wantMinor, tt.wantPatch)
+			}
+			if sv.IsPrerelease != tt.wantPrerelease || sv.Channel != tt.wantChannel {
+				t.Errorf("Parse(%q) prerelease = %v (%q), want %v (%q)", tt.tag, sv.IsPrerelease, sv.Channel, tt.wantPrerelease, tt.wantChannel)
+			}
+		})
+	}
+}
+
+func TestPEP440Compare(t *testing.T) {
+	// Versions in ascending order as specified by PEP 440.
+	ordered := []string{
+		"1.0.dev456",
+		"1.0a1",
+		"1.0a2.dev456",
+		"1.0a12.dev456",
+		"1.0a12",
+		"1.0b1.dev456",
+		"1.0b2",
+		"1.0b2.post345.dev456",
+		"1.0b2.post345",
+		"1.0rc1.dev456",
+		"1.0rc1",
+		"1.0",
+		"1.0+abc.5",
+		"1.0.post456.dev34",
+		"1.0.post456",
+		"1.0.15",
+		"1.1.dev1",
+		"1!0.1",
+	}
+
+	scheme := pep440Scheme{}
+	for i := 1; i < len(ordered); i++ {
+		lower, err := scheme.Parse(ordered[i-1])
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", ordered[i-1], err)
+		}
+		higher, err := scheme.Parse(ordered[i])
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", ordered[i], err)
+		}
+		if got := scheme.Compare(higher, lower); got != 1 {
+			t.Errorf("Compare(%s, %s) = %d, want 1", ordered[i], ordered[i-1], got)
+		}
+		if got := scheme.Compare(lower, higher); got != -1 {
+			t.Errorf("Compare(%s, %s) = %d, want -1", ordered[i-1], ordered[i], got)
+		}
+	}
+
+	a, _ := scheme.Parse("1.4")
+	b, _ := scheme.Parse("v1.4.0")
+	if got := scheme.Compare(a, b); got != 0 {
+		t.Errorf("Compare(1.4, v1.4.0) = %d, want 0", got)
+	}
+}
+
+func TestPEP440Compare_LocalLabel(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "1.0+abc.10", b: "1.0+abc.9", want: 1},
+		{a: "1.0+abc.9", b: "1.0+abc.10", want: -1},
+		{a: "1.0+1", b: "1.0+abc", want: 1},
+		{a: "1.0+abc", b: "1.0+1", want: -1},
+		{a: "1.0+abc.1", b: "1.0+abc", want: 1},
+		{a: "1.0+abc-10", b: "1.0+abc_9", want: 1},
+		{a: "1.0+abc.10", b: "1.0+abc-10", want: 0},
+		{a: "1.0+abc.010", b: "1.0+abc.10", want: 0},
+		{a: "1.0+ubuntu.2", b: "1.0+debian.2", want: 1},
+	}
+
+	scheme := pep440Scheme{}
+	for _, tt := range tests {
+		a, err := scheme.Parse(tt.a)
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", tt.a, err)
+		}
+		b, err := scheme.Parse(tt.b)
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", tt.b, err)
+		}
+		if got := scheme.Compare(a, b); got != tt.want {
+			t.Errorf("Compare(%s, %s) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestActionRunAll_PEP440(t *testing.T) {
+	var createdRefs []string
+	mock := &mockGitHubClient{
+		listTagsFunc: func(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryTag, *github.Response, error) {
+			return []*github.RepositoryTag{
+				makeTag("1.3.2", "sha132"),
+				makeTag("1.4.0", "sha140"),
+				makeTag("1.4.0.post2", "sha140post2"),
+				makeTag("1.5.0rc1", "sha150rc1"),
+				makeTag("2.0.0.dev1", "sha200dev1"),
+			}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+		},
+		createRefFunc: func(ctx context.Context, owner, repo string, ref github.CreateRef) (*github.Reference, *github.Response, error) {
+			createdRefs = append(createdRefs, ref.Ref+"="+ref.SHA)
+			return &github.Reference{}, &github.Response{Response: &http.Response{StatusCode: http.StatusCreated}}, nil
+		},
+	}
+
+	config := Config{
+		GitHubRepo:      "owner/repo",
+		SyncAllTags:     true,
+		SyncMajor:       true,
+		SyncMinor:       true,
+		SkipPrereleases: true,
+		VersionScheme:   schemePEP440,
+	}
+
+	action := NewAction(mock, config, nil)
+	if err := action.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	want := []string{
+		"refs/tags/1.3=sha132",
+		"refs/tags/1.4=sha140post2",
+		"refs/tags/1=sha140post2",
+	}
+	slices.Sort(createdRefs)
+	if !slices.Equal(createdRefs, want) {
+		t.Errorf("created refs = %v, want %v", createdRefs, want)
+	}
+}
+
+func TestActionRun_PEP440Prerelease(t *testing.T) {
+	var createdRefs []string
+	mock := &mockGitHubClient{
+		createRefFunc: func(ctx context.Context, owner, repo string, ref github.CreateRef) (*github.Reference, *github.Response, error) {
+			createdRefs = append(createdRefs, ref.Ref)
+			return &github.Reference{}, &github.Response{Response: &http.Response{StatusCode: http.StatusCreated}}, nil
+		},
+	}
+
+	config := Config{
+		GitHubRepo:      "owner/repo",
+		GitRef:          "refs/tags/1.4.0rc1",
+		CommitSHA:       "abc123",
+		SyncChannels:    true,
+		SyncMajor:       true,
+		SkipPrereleases: true,
+		VersionScheme:   schemePEP440,
+	}
+
+	action := NewAction(mock, config, nil)
+	if err := action.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	want := []string{"refs/tags/1-rc"}
+	if !slices.Equal(createdRefs, want) {
+		t.Errorf("created refs = %v, want %v", createdRefs, want)
+	}
+}
+
+func TestActionRunInspect_PEP440FloatingTags(t *testing.T) {
+	// The floating tags are listed along with the releases. 1.4 equals 1.4.0 under PEP 440 but
+	// must not be taken for a release of its own line.
+	mock := verifyMock(t, []*github.RepositoryTag{
+		makeTag("1.4.0", "sha140"),
+		makeTag("1.4.1", "sha141"),
+		makeTag("1.4", "sha140"),
+		makeTag("1", "sha140"),
+		makeTag("v2.0.0", "sha200"),
+		makeTag("v2.0", "sha200"),
+		makeTag("v2", "sha200"),
+	}, map[string]string{
+		"tags/1.4":  "sha140",
+		"tags/1":    "sha140",
+		"tags/v2.0": "sha200",
+		"tags/v2":   "sha200",
+	})
+	config := Config{
+		GitHubRepo:      "owner/repo",
+		SyncMajor:       true,
+		SyncMinor:       true,
+		SkipPrereleases: true,
+		VersionScheme:   schemePEP440,
+	}
+	action := NewAction(mock, config, nil)
+
+	lines, err := action.inspect(context.Background(), "owner", "repo")
+	if err != nil {
+		t.Fatalf("inspect() error = %v", err)
+	}
+	var got []string
+	for _, l := range lines {
+		got = append(got, fmt.Sprintf("%s=%s/%s/%d", l.Line, l.Latest, l.Status, l.Releases))
+	}
+	want := []string{"v1=1.4.1/stale/2", "v2=v2.0.0/in-sync/1", "v1.4=1.4.1/stale/2", "v2.0=v2.0.0/in-sync/1"}
+	if !slices.Equal(got, want) {
+		t.Errorf("lines = %v, want %v", got, want)
+	}
+}
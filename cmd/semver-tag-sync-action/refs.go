@@ -0,0 +1,403 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v90/github"
+)
+
+// floatingLevel identifies which part of a version a floating ref follows.
+type floatingLevel string
+
+const (
+	levelMajor  floatingLevel = "major"
+	levelMinor  floatingLevel = "minor"
+	levelPatch  floatingLevel = "patch" // Only with four-part versions, e.g., v1.2.3 for v1.2.3.4
+	levelLatest floatingLevel = "latest"
+
+	// Prerelease channel levels, e.g., v2-rc, v2.0-rc and next.
+	levelMajorChannel floatingLevel = "major-channel"
+	levelMinorChannel floatingLevel = "minor-channel"
+	levelNext         floatingLevel = "next"
+)
+
+// repoWide reports whether a level has a single repository-wide group that only moves forward.
+func (l floatingLevel) repoWide() bool {
+	return l == levelLatest || l == levelNext
+}
+
+// Ref types selecting which namespaces a floating level is synced to.
+const (
+	refTypeTag    = "tag"
+	refTypeBranch = "branch"
+	refTypeBoth   = "both"
+)
+
+// Policies for the major floating level of 0.x versions, whose minor lines are incompatible.
+const (
+	zeroMajorFloat = "float" // v0 floats across all 0.x releases
+	zeroMajorSkip  = "skip"  // No major floating ref while the major version is 0
+	zeroMajorMinor = "minor" // Each 0.x minor line gets its own major-equivalent ref, e.g., v0.3
+)
+
+// Default name templates for floating tags and branches.
+const (
+	defaultMajorTagTemplate    = "{prefix}{major}"
+	defaultMinorTagTemplate    = "{prefix}{major}.{minor}"
+	defaultMajorBranchTemplate = "release/{prefix}{major}"
+	defaultMinorBranchTemplate = "release/{prefix}{major}.{minor}"
+	patchTagTemplate           = "{prefix}{major}.{minor}.{patch}"
+	defaultLatestTag           = "latest"
+	defaultNextTag             = "next"
+	majorChannelTemplate       = "{prefix}{major}-{channel}"
+	minorChannelTemplate       = "{prefix}{major}.{minor}-{channel}"
+)
+
+// levels returns the floating levels enabled in the configuration, globally or by an override.
+func (a *Action) levels() []floatingLevel {
+	var levels []floatingLevel
+	if a.config.syncMajorAnywhere() {
+		levels = append(levels, levelMajor)
+	}
+	if a.config.syncMinorAnywhere() {
+		levels = append(levels, levelMinor)
+	}
+	if a.config.FourPartVersions && a.config.SyncPatch {
+		levels = append(levels, levelPatch)
+	}
+	if a.config.SyncLatest {
+		levels = append(levels, levelLatest)
+	}
+	if a.config.SyncChannels {
+		if a.config.syncMajorAnywhere() {
+			levels = append(levels, levelMajorChannel)
+		}
+		if a.config.syncMinorAnywhere() {
+			levels = append(levels, levelMinorChannel)
+		}
+	}
+	if a.config.SyncNext {
+		levels = append(levels, levelNext)
+	}
+	return levels
+}
+
+// eligibleLevels returns the enabled levels that have a group for sv.
+func (a *Action) eligibleLevels(sv *SemVer) []floatingLevel {
+	var levels []floatingLevel
+	for _, level := range a.levels() {
+		if a.groupKey(level, sv) != "" {
+			levels = append(levels, level)
+		}
+	}
+	return levels
+}
+
+// groupKey returns the key of the group sv belongs to at the given level, or "" if sv is not eligible.
+func (a *Action) groupKey(level floatingLevel, sv *SemVer) string {
+	settings := a.settings(sv)
+	switch level {
+	case levelMajor, levelMajorChannel:
+		if !settings.syncMajor {
+			return ""
+		}
+	case levelMinor, levelMinorChannel:
+		if !settings.syncMinor {
+			return ""
+		}
+	}
+
+	switch level {
+	case levelMajor, levelMinor, levelPatch:
+		if sv.IsPrerelease && settings.skipPrereleases {
+			return ""
+		}
+		if level == levelMajor {
+			switch a.zeroMajorPolicy(sv) {
+			case zeroMajorSkip:
+				return ""
+			case zeroMajorMinor:
+				return a.scheme.MinorKey(sv)
+			}
+			return a.scheme.MajorKey(sv)
+		}
+		if level == levelPatch {
+			if sv.Revision == "" {
+				return ""
+			}
+			return fmt.Sprintf("%s.%s", a.scheme.MinorKey(sv), sv.Patch)
+		}
+		// Two-segment versions (e.g., CalVer YYYY.MM) are their own minor line.
+		if sv.Patch == "" {
+			return ""
+		}
+		return a.scheme.MinorKey(sv)
+	case levelLatest:
+		// The latest tag only ever follows stable releases.
+		if sv.IsPrerelease {
+			return ""
+		}
+		return string(levelLatest)
+	case levelMajorChannel, levelMinorChannel, levelNext:
+		// Channel tags only ever follow prereleases.
+		channel := sv.Channel
+		if channel == "" {
+			return ""
+		}
+		switch level {
+		case levelMajorChannel:
+			return a.scheme.MajorKey(sv) + "-" + channel
+		case levelMinorChannel:
+			if sv.Patch == "" {
+				return ""
+			}
+			return a.scheme.MinorKey(sv) + "-" + channel
+		}
+		return string(levelNext)
+	}
+	return ""
+}
+
+// errFloatingRef is returned when parsing a tag that is a floating ref maintained by the action.
+var errFloatingRef = errors.New("tag is a floating ref maintained by the action, not a release")
+
+// isFloatingRef reports whether tag is the name of a floating tag the action maintains, e.g., 1.4
+// under a scheme that would parse it as a release. The names rendered for the listed releases are
+// known once tags were listed; otherwise only the floating tags of sv itself are considered.
+func (a *Action) isFloatingRef(tag string, sv *SemVer) bool {
+	return a.floating[tag] || slices.Contains(a.floatingTags(sv), tag)
+}
+
+// floatingTagNames returns the names of the floating tags of all release tags in tags.
+func (a *Action) floatingTagNames(tags []*github.RepositoryTag) map[string]bool {
+	names := make(map[string]bool)
+	for _, tag := range tags {
+		sv, err := a.scheme.Parse(tag.GetName())
+		if err != nil {
+			continue
+		}
+		for _, name := range a.floatingTags(sv) {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// floatingTags returns the names of the floating tags that follow sv at the enabled levels.
+func (a *Action) floatingTags(sv *SemVer) []string {
+	var names []string
+	for _, level := range a.levels() {
+		if a.groupKey(level, sv) == "" {
+			continue
+		}
+		for _, ref := range a.floatingRefs(level, sv) {
+			if name, ok := strings.CutPrefix(ref, "tags/"); ok {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// floatingRefs returns the refs (e.g., "tags/v1", "heads/release/v1") that follow sv at the given level.
+func (a *Action) floatingRefs(level floatingLevel, sv *SemVer) []string {
+	switch level {
+	case levelLatest:
+		return []string{"tags/" + valueOrDefault(a.config.LatestTag, defaultLatestTag)}
+	case levelNext:
+		return []string{"tags/" + valueOrDefault(a.config.NextTag, defaultNextTag)}
+	case levelMajorChannel:
+		return []string{"tags/" + renderRefTemplate(majorChannelTemplate, sv)}
+	case levelMinorChannel:
+		return []string{"tags/" + renderRefTemplate(minorChannelTemplate, sv)}
+	case levelPatch:
+		return []string{"tags/" + renderRefTemplate(patchTagTemplate, sv)}
+	}
+
+	rendered := sv
+	if level == levelMajor && a.zeroMajorPolicy(sv) == zeroMajorMinor {
+		// Render the 0.x minor line in place of the major version, e.g., v0.3.
+		zero := *sv
+		zero.Major = sv.Major + "." + sv.Minor
+		rendered = &zero
+	}
+
+	var refType, tagTemplate, branchTemplate string
+	switch level {
+	case levelMajor:
+		refType = a.config.MajorRefType
+		tagTemplate = valueOrDefault(a.config.MajorTagTemplate, defaultMajorTagTemplate)
+		branchTemplate = valueOrDefault(a.config.MajorBranchTemplate, defaultMajorBranchTemplate)
+	case levelMinor:
+		refType = a.config.MinorRefType
+		tagTemplate = valueOrDefault(a.config.MinorTagTemplate, defaultMinorTagTemplate)
+		branchTemplate = valueOrDefault(a.config.MinorBranchTemplate, defaultMinorBranchTemplate)
+	}
+
+	tag := "tags/" + renderRefTemplate(tagTemplate, rendered)
+	branch := "heads/" + renderRefTemplate(branchTemplate, rendered)
+	var refs []string
+	switch refType {
+	case refTypeBranch:
+		refs = []string{branch}
+	case refTypeBoth:
+		refs = []string{tag, branch}
+	default:
+		refs = []string{tag}
+	}
+
+	if level == levelMinor && a.settings(sv).syncMajor && a.zeroMajorPolicy(sv) == zeroMajorMinor {
+		// The major level already maintains the same refs for this 0.x line.
+		major := a.floatingRefs(levelMajor, sv)
+		refs = slices.DeleteFunc(refs, func(ref string) bool { return slices.Contains(major, ref) })
+	}
+	return refs
+}
+
+// zeroMajorPolicy returns the major level policy for sv, which is always float unless its major version is 0.
+func (a *Action) zeroMajorPolicy(sv *SemVer) string {
+	if major, err := strconv.Atoi(sv.Major); err != nil || major != 0 {
+		return zeroMajorFloat
+	}
+	return valueOrDefault(a.config.ZeroMajor, zeroMajorFloat)
+}
+
+// renderRefTemplate substitutes the {prefix}, {major}, {minor}, {patch} and {channel} placeholders in a ref name template.
+func renderRefTemplate(tmpl string, sv *SemVer) string {
+	return strings.NewReplacer(
+		"{prefix}", sv.Prefix,
+		"{major}", sv.Major,
+		"{minor}", sv.Minor,
+		"{patch}", sv.Patch,
+		"{channel}", sv.Channel,
+	).Replace(tmpl)
+}
+
+// valueOrDefault returns value, or def if value is empty.
+func valueOrDefault(value, def string) string {
+	if value == "" {
+		return def
+	}
+	return value
+}
+
+// refKind returns a human readable kind ("tag" or "branch") for a short ref.
+func refKind(ref string) string {
+	if strings.HasPrefix(ref, "heads/") {
+		return "branch"
+	}
+	return "tag"
+}
+
+// refDisplayName strips the namespace from a short ref for log output.
+func refDisplayName(ref string) string {
+	if name, ok := strings.CutPrefix(ref, "tags/"); ok {
+		return name
+	}
+	return ref
+}
+
+// validateRefType checks that a ref type option holds a supported value.
+func validateRefType(option, value string) error {
+	switch value {
+	case "", refTypeTag, refTypeBranch, refTypeBoth:
+		return nil
+	default:
+		return fmt.Errorf("invalid %s %q (expected %s, %s or %s)", option, value, refTypeTag, refTypeBranch, refTypeBoth)
+	}
+}
+
+// validateZeroMajor checks that a 0.x policy option holds a supported value.
+func validateZeroMajor(value string) error {
+	switch value {
+	case "", zeroMajorFloat, zeroMajorSkip, zeroMajorMinor:
+		return nil
+	default:
+		return fmt.Errorf("invalid --zero-major %q (expected %s, %s or %s)", value, zeroMajorFloat, zeroMajorSkip, zeroMajorMinor)
+	}
+}
+
+// validateNameTemplate checks that a name template contains the placeholders its level requires,
+// renders to a valid git ref name and cannot be mistaken for a release tag of the version scheme.
+func validateNameTemplate(scheme VersionScheme, option, tmpl string, placeholders ...string) error {
+	if tmpl == "" {
+		return nil
+	}
+	for _, p := range placeholders {
+		if !strings.Contains(tmpl, p) {
+			return fmt.Errorf("invalid %s %q (must contain %s)", option, tmpl, p)
+		}
+	}
+	stripped := strings.NewReplacer("{prefix}", "", "{major}", "", "{minor}", "").Replace(tmpl)
+	if strings.ContainsAny(stripped, "{}") {
+		return fmt.Errorf("invalid %s %q (unknown placeholder, expected {prefix}, {major} or {minor})", option, tmpl)
+	}
+	name := renderRefTemplate(tmpl, scheme.Sample())
+	if err := checkRefFormat(name); err != nil {
+		return fmt.Errorf("invalid %s %q: %w", option, tmpl, err)
+	}
+	if _, err := scheme.Parse(name); err == nil {
+		return fmt.Errorf("invalid %s %q (renders %q, which looks like a release tag)", option, tmpl, name)
+	}
+	return nil
+}
+
+// validateChannelTag checks that a repository-wide tag name is a valid git ref and no release tag of the version scheme.
+func validateChannelTag(scheme VersionScheme, option, name string) error {
+	if name == "" {
+		return nil
+	}
+	if err := checkRefFormat(name); err != nil {
+		return fmt.Errorf("invalid %s %q: %w", option, name, err)
+	}
+	if _, err := scheme.Parse(name); err == nil {
+		return fmt.Errorf("invalid %s %q (looks like a release tag)", option, name)
+	}
+	return nil
+}
+
+// checkRefFormat applies the rules of git check-ref-format to a ref name below refs/tags/ or refs/heads/.
+func checkRefFormat(name string) error {
+	switch {
+	case name == "":
+		return fmt.Errorf("ref name is empty")
+	case name == "@":
+		return fmt.Errorf("ref name %q is not allowed", name)
+	case strings.HasPrefix(name, "/"), strings.HasSuffix(name, "/"):
+		return fmt.Errorf("ref name %q cannot begin or end with a slash", name)
+	case strings.HasSuffix(name, "."):
+		return fmt.Errorf("ref name %q cannot end with a dot", name)
+	case strings.Contains(name, "//"):
+		return fmt.Errorf("ref name %q cannot contain consecutive slashes", name)
+	case strings.Contains(name, ".."):
+		return fmt.Errorf("ref name %q cannot contain \"..\"", name)
+	case strings.Contains(name, "@{"):
+		return fmt.Errorf("ref name %q cannot contain \"@{\"", name)
+	}
+	for _, r := range name {
+		if r < 0x20 || r == 0x7f || strings.ContainsRune(" ~^:?*[\\", r) {
+			return fmt.Errorf("ref name %q contains invalid character %q", name, r)
+		}
+	}
+	for _, component := range strings.Split(name, "/") {
+		if strings.HasPrefix(component, ".") {
+			return fmt.Errorf("ref name %q has a component beginning with a dot", name)
+		}
+		if strings.HasSuffix(component, ".lock") {
+			return fmt.Errorf("ref name %q has a component ending with .lock", name)
+		}
+	}
+	return nil
+}
+
+// capitalize upper-cases the first letter of a log message fragment.
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
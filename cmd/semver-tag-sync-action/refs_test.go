@@ -0,0 +1,289 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/google/go-github/v90/github"
+)
+
+func TestFloatingRefs(t *testing.T) {
+	sv, err := ParseSemVer("v1.2.3")
+	if err != nil {
+		t.Fatalf("ParseSemVer() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		config Config
+		level  floatingLevel
+		want   []string
+	}{
+		{
+			name:  "major tag by default",
+			level: levelMajor,
+			want:  []string{"tags/v1"},
+		},
+		{
+			name:   "minor branch with default template",
+			config: Config{MinorRefType: refTypeBranch},
+			level:  levelMinor,
+			want:   []string{"heads/release/v1.2"},
+		},
+		{
+			name:   "major tag and branch with custom template",
+			config: Config{MajorRefType: refTypeBoth, MajorBranchTemplate: "v{major}.x"},
+			level:  levelMajor,
+			want:   []string{"tags/v1", "heads/v1.x"},
+		},
+		{
+			name:   "custom major tag template",
+			config: Config{MajorTagTemplate: "{prefix}{major}-stable"},
+			level:  levelMajor,
+			want:   []string{"tags/v1-stable"},
+		},
+		{
+			name:   "custom minor tag template",
+			config: Config{MinorTagTemplate: "stable-{prefix}{major}.{minor}"},
+			level:  levelMinor,
+			want:   []string{"tags/stable-v1.2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action := NewAction(&mockGitHubClient{}, tt.config, nil)
+			if got := action.floatingRefs(tt.level, sv); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("floatingRefs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestActionRunAll_SyncsBranchesAndTags(t *testing.T) {
+	var createdRefs []string
+	mock := &mockGitHubClient{
+		listTagsFunc: func(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryTag, *github.Response, error) {
+			return []*github.RepositoryTag{
+				makeTag("v1.0.0", "sha100"),
+				makeTag("v1.1.0", "sha110"),
+			}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+		},
+		getRefFunc: func(ctx context.Context, owner, repo, ref string) (*github.Reference, *github.Response, error) {
+			return nil, &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, errors.New("not found")
+		},
+		createRefFunc: func(ctx context.Context, owner, repo string, ref github.CreateRef) (*github.Reference, *github.Response, error) {
+			createdRefs = append(createdRefs, ref.Ref+"="+ref.SHA)
+			return &github.Reference{}, &github.Response{Response: &http.Response{StatusCode: http.StatusCreated}}, nil
+		},
+	}
+
+	config := Config{
+		GitHubRepo:   "owner/repo",
+		SyncMajor:    true,
+		SyncMinor:    true,
+		SyncAllTags:  true,
+		MajorRefType: refTypeBoth,
+		MinorRefType: refTypeBranch,
+	}
+
+	action := NewAction(mock, config, nil)
+	if err := action.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	want := []string{
+		"refs/heads/release/v1.0=sha100",
+		"refs/heads/release/v1.1=sha110",
+		"refs/heads/release/v1=sha110",
+		"refs/tags/v1=sha110",
+	}
+	sort.Strings(createdRefs)
+	if !reflect.DeepEqual(createdRefs, want) {
+		t.Errorf("created refs = %v, want %v", createdRefs, want)
+	}
+}
+
+func TestValidateNameTemplate(t *testing.T) {
+	lenient := semverScheme{lenient: true}
+	calver, err := parseCalVerFormat(defaultCalVerFormat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	calverMonths, err := parseCalVerFormat("YYYY.0M")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name         string
+		scheme       VersionScheme
+		tmpl         string
+		placeholders []string
+		wantErr      bool
+	}{
+		{"default major", nil, defaultMajorTagTemplate, []string{"{major}"}, false},
+		{"x suffix", nil, "{prefix}{major}.x", []string{"{major}"}, false},
+		{"latest suffix", nil, "{major}-latest", []string{"{major}"}, false},
+		{"stable prefix", nil, "stable-{prefix}{major}.{minor}", []string{"{major}", "{minor}"}, false},
+		{"missing placeholder", nil, "{prefix}{major}", []string{"{major}", "{minor}"}, true},
+		{"unknown placeholder", nil, "{prefix}{major}-{channel}", []string{"{major}"}, true},
+		{"looks like release tag", nil, "{prefix}{major}.{minor}.0", []string{"{major}", "{minor}"}, true},
+		{"invalid ref characters", nil, "{prefix}{major}~latest", []string{"{major}"}, true},
+		{"lock suffix", nil, "{prefix}{major}.lock", []string{"{major}"}, true},
+		{"two parts under semver", nil, "{major}.{minor}", []string{"{major}", "{minor}"}, false},
+		{"two parts under lenient", lenient, "{major}.{minor}", []string{"{major}", "{minor}"}, true},
+		{"two parts under pep440", pep440Scheme{}, "{major}.{minor}", []string{"{major}", "{minor}"}, true},
+		{"release tag under pep440", pep440Scheme{}, "{prefix}{major}.{minor}.0", []string{"{major}", "{minor}"}, true},
+		{"x suffix under pep440", pep440Scheme{}, "{prefix}{major}.{minor}.x", []string{"{major}", "{minor}"}, false},
+		{"release tag under calver", calver, "{prefix}{major}.{minor}.0", []string{"{major}", "{minor}"}, true},
+		{"x suffix under calver", calver, "{prefix}{major}.{minor}.x", []string{"{major}", "{minor}"}, false},
+		{"default minor under two-part calver", calverMonths, "{prefix}{major}.{minor}", []string{"{major}", "{minor}"}, true},
+		{"default major under two-part calver", calverMonths, defaultMajorTagTemplate, []string{"{major}"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := tt.scheme
+			if scheme == nil {
+				scheme = semverScheme{}
+			}
+			err := validateNameTemplate(scheme, "--template", tt.tmpl, tt.placeholders...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateNameTemplate(%q) error = %v, wantErr %v", tt.tmpl, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckRefFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		ref     string
+		wantErr bool
+	}{
+		{"simple", "v1", false},
+		{"nested", "release/v1.2", false},
+		{"empty", "", true},
+		{"at sign", "@", true},
+		{"leading slash", "/v1", true},
+		{"trailing slash", "v1/", true},
+		{"trailing dot", "v1.", true},
+		{"double dot", "v1..2", true},
+		{"double slash", "release//v1", true},
+		{"reflog syntax", "v1@{0}", true},
+		{"space", "v 1", true},
+		{"colon", "v1:2", true},
+		{"component with leading dot", "release/.v1", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkRefFormat(tt.ref)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkRefFormat(%q) error = %v, wantErr %v", tt.ref, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestActionRunAll_ZeroMajor(t *testing.T) {
+	tags := []*github.RepositoryTag{
+		makeTag("v0.3.0", "sha030"),
+		makeTag("v0.3.1", "sha031"),
+		makeTag("v0.4.0", "sha040"),
+		makeTag("v1.0.0", "sha100"),
+	}
+
+	tests := []struct {
+		name   string
+		config Config
+		want   []string
+	}{
+		{
+			name:   "v0 floats across minor lines by default",
+			config: Config{SyncMajor: true},
+			want:   []string{"refs/tags/v0=sha040", "refs/tags/v1=sha100"},
+		},
+		{
+			name:   "skip major tag while major is 0",
+			config: Config{SyncMajor: true, ZeroMajor: zeroMajorSkip},
+			want:   []string{"refs/tags/v1=sha100"},
+		},
+		{
+			name:   "minor line as major equivalent",
+			config: Config{SyncMajor: true, ZeroMajor: zeroMajorMinor, MajorTagTemplate: "{prefix}{major}.x"},
+			want:   []string{"refs/tags/v0.3.x=sha031", "refs/tags/v0.4.x=sha040", "refs/tags/v1.x=sha100"},
+		},
+		{
+			name:   "major equivalent is not synced twice with the minor tag",
+			config: Config{SyncMajor: true, SyncMinor: true, ZeroMajor: zeroMajorMinor},
+			want: []string{
+				"refs/tags/v0.3=sha031",
+				"refs/tags/v0.4=sha040",
+				"refs/tags/v1.0=sha100",
+				"refs/tags/v1=sha100",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var createdRefs []string
+			mock := &mockGitHubClient{
+				listTagsFunc: func(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryTag, *github.Response, error) {
+					return tags, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+				},
+				createRefFunc: func(ctx context.Context, owner, repo string, ref github.CreateRef) (*github.Reference, *github.Response, error) {
+					createdRefs = append(createdRefs, ref.Ref+"="+ref.SHA)
+					return &github.Reference{}, &github.Response{Response: &http.Response{StatusCode: http.StatusCreated}}, nil
+				},
+			}
+
+			config := tt.config
+			config.GitHubRepo = "owner/repo"
+			config.SyncAllTags = true
+
+			action := NewAction(mock, config, nil)
+			if err := action.Run(context.Background()); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+
+			sort.Strings(createdRefs)
+			if !reflect.DeepEqual(createdRefs, tt.want) {
+				t.Errorf("created refs = %v, want %v", createdRefs, tt.want)
+			}
+		})
+	}
+}
+
+func TestActionRun_ZeroMajorSkip(t *testing.T) {
+	var createdRefs []string
+	mock := &mockGitHubClient{
+		createRefFunc: func(ctx context.Context, owner, repo string, ref github.CreateRef) (*github.Reference, *github.Response, error) {
+			createdRefs = append(createdRefs, ref.Ref)
+			return &github.Reference{}, &github.Response{Response: &http.Response{StatusCode: http.StatusCreated}}, nil
+		},
+	}
+
+	config := Config{
+		GitHubRepo: "owner/repo",
+		GitRef:     "refs/tags/v0.4.1",
+		CommitSHA:  "abc123",
+		SyncMajor:  true,
+		SyncMinor:  true,
+		ZeroMajor:  zeroMajorSkip,
+	}
+
+	action := NewAction(mock, config, nil)
+	if err := action.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if want := []string{"refs/tags/v0.4"}; !reflect.DeepEqual(createdRefs, want) {
+		t.Errorf("created refs = %v, want %v", createdRefs, want)
+	}
+}
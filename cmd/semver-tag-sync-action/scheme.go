@@ -0,0 +1,120 @@
+package main
+
+import "fmt"
+
+// Version schemes selectable with --version-scheme.
+const (
+	schemeSemVer = "semver"
+	schemeCalVer = "calver"
+	schemePEP440 = "pep440"
+)
+
+// VersionScheme parses, orders and groups the release tags of one versioning scheme.
+// Parse classifies prereleases by setting IsPrerelease and Channel on the parsed version.
+type VersionScheme interface {
+	// Parse parses a release tag, returning an error if it is not a version of this scheme.
+	Parse(tag string) (*SemVer, error)
+	// Compare returns -1, 0 or 1 if a is lower than, equal to or higher than b.
+	Compare(a, b *SemVer) int
+	// MajorKey returns the key of the major version line of sv (e.g., "v1").
+	MajorKey(sv *SemVer) string
+	// MinorKey returns the key of the minor version line of sv (e.g., "v1.2").
+	MinorKey(sv *SemVer) string
+	// Sample returns a release of this scheme, used to validate name templates.
+	Sample() *SemVer
+}
+
+// newVersionScheme returns the version scheme selected in the configuration.
+func newVersionScheme(config Config) (VersionScheme, error) {
+	if config.FourPartVersions && valueOrDefault(config.VersionScheme, schemeSemVer) != schemeSemVer {
+		return nil, fmt.Errorf("--four-part-versions requires --version-scheme=%s", schemeSemVer)
+	}
+	if config.StrictSemVer && valueOrDefault(config.VersionScheme, schemeSemVer) != schemeSemVer {
+		return nil, fmt.Errorf("--strict-semver requires --version-scheme=%s", schemeSemVer)
+	}
+	if config.LenientSemVer {
+		switch {
+		case valueOrDefault(config.VersionScheme, schemeSemVer) != schemeSemVer:
+			return nil, fmt.Errorf("--lenient-semver requires --version-scheme=%s", schemeSemVer)
+		case config.StrictSemVer:
+			return nil, fmt.Errorf("--lenient-semver cannot be combined with --strict-semver")
+		case config.FourPartVersions:
+			return nil, fmt.Errorf("--lenient-semver cannot be combined with --four-part-versions")
+		}
+		if err := validateCanonicalPrefix(config.CanonicalPrefix); err != nil {
+			return nil, err
+		}
+	}
+	switch config.VersionScheme {
+	case "", schemeSemVer:
+		return semverScheme{
+			fourPart: config.FourPartVersions,
+			strict:   config.StrictSemVer,
+			lenient:  config.LenientSemVer,
+			prefix:   config.CanonicalPrefix,
+		}, nil
+	case schemeCalVer:
+		return parseCalVerFormat(valueOrDefault(config.CalVerFormat, defaultCalVerFormat))
+	case schemePEP440:
+		return pep440Scheme{}, nil
+	default:
+		return nil, fmt.Errorf("invalid --version-scheme %q (expected %s, %s or %s)", config.VersionScheme, schemeSemVer, schemeCalVer, schemePEP440)
+	}
+}
+
+// semverScheme implements VersionScheme for semantic versioning tags like v1.2.3,
+// or four-part versions like v1.2.3.4 if fourPart is set. With strict set, tags must
+// follow the full SemVer 2.0 grammar; with lenient set, tags like V1.2 are accepted
+// and normalized to the canonical prefix.
+type semverScheme struct {
+	fourPart bool
+	strict   bool
+	lenient  bool
+	prefix   string
+}
+
+// Parse parses a semantic version tag.
+func (s semverScheme) Parse(tag string) (*SemVer, error) {
+	switch {
+	case s.strict && s.fourPart:
+		return parseStrict(tag, 4)
+	case s.strict:
+		return ParseStrictSemVer(tag)
+	case s.fourPart:
+		return ParseFourPartVersion(tag)
+	case s.lenient:
+		return ParseLenientSemVer(tag, s.prefix)
+	}
+	return ParseSemVer(tag)
+}
+
+// Compare orders two versions by semantic versioning precedence.
+func (semverScheme) Compare(a, b *SemVer) int {
+	switch {
+	case SemVerGreaterThan(a, b):
+		return 1
+	case SemVerGreaterThan(b, a):
+		return -1
+	}
+	return 0
+}
+
+// MajorKey returns the major version tag of sv.
+func (semverScheme) MajorKey(sv *SemVer) string {
+	return sv.MajorTag()
+}
+
+// MinorKey returns the minor version tag of sv.
+func (semverScheme) MinorKey(sv *SemVer) string {
+	return sv.MinorTag()
+}
+
+// Sample returns the release v1.2.3, or v1.2.3.4 with four-part versions.
+func (s semverScheme) Sample() *SemVer {
+	tag := "v1.2.3"
+	if s.fourPart {
+		tag = "v1.2.3.4"
+	}
+	sv, _ := s.Parse(tag)
+	return sv
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// plannedChange is a ref change a dry run would have made, kept for the plan script.
+type plannedChange struct {
+	ref     string // Full ref name, e.g. "refs/tags/v1"
+	fromSHA string // Empty if the ref does not exist yet
+	toSHA   string // Empty if the ref is deleted
+	release string
+}
+
+// planChange remembers a ref change skipped by a dry run so it can be written to the plan script.
+func (a *Action) planChange(ref, fromSHA, toSHA, release string) {
+	if a.config.PlanScript == "" {
+		return
+	}
+	a.planned = append(a.planned, plannedChange{ref: ref, fromSHA: fromSHA, toSHA: toSHA, release: release})
+}
+
+// writePlanScript writes the planned changes as a shell script of git commands to the configured path.
+func (a *Action) writePlanScript() error {
+	if a.config.PlanScript == "" {
+		return nil
+	}
+	if err := os.WriteFile(a.config.PlanScript, []byte(a.planScript()), 0o755); err != nil {
+		return fmt.Errorf("failed to write plan script: %w", err)
+	}
+	a.log.Info("Wrote plan script",
+		slog.String("path", a.config.PlanScript),
+		slog.Int("changes", len(a.planned)),
+	)
+	return nil
+}
+
+// planScript renders the planned changes as git commands. Every push carries the SHA the ref
+// pointed to when the plan was made as a --force-with-lease guard, so it fails if the ref moved since.
+func (a *Action) planScript() string {
+	var b strings.Builder
+	b.WriteString("#!/bin/sh\n")
+	fmt.Fprintf(&b, "# Floating ref changes planned by semver-tag-sync-action for %s.\n", a.config.GitHubRepo)
+	b.WriteString("# Run it from an up-to-date clone with push access to origin. A push fails if its\n")
+	b.WriteString("# ref no longer points where it did when the plan was made.\n")
+	b.WriteString("set -eu\n")
+	if len(a.planned) == 0 {
+		b.WriteString("\n# Nothing to do, every floating ref is up to date.\n")
+		return b.String()
+	}
+
+	for _, change := range a.planned {
+		refName := strings.TrimPrefix(change.ref, "refs/")
+		kind, name := refKind(refName), refDisplayName(refName)
+		lease := shellQuote("--force-with-lease=" + change.ref + ":" + change.fromSHA)
+
+		b.WriteString("\n")
+		switch {
+		case change.toSHA == "":
+			fmt.Fprintf(&b, "# Delete %s %s (at %s)\n", kind, name, change.fromSHA)
+			fmt.Fprintf(&b, "git push %s origin --delete %s\n", lease, shellQuote(change.ref))
+			continue
+		case change.fromSHA == "":
+			fmt.Fprintf(&b, "# Create %s %s at %s\n", kind, name, describeTarget(change))
+		default:
+			fmt.Fprintf(&b, "# Update %s %s from %s to %s\n", kind, name, change.fromSHA, describeTarget(change))
+		}
+		if strings.HasPrefix(refName, "tags/") {
+			fmt.Fprintf(&b, "git tag -f %s %s\n", shellQuote(name), change.toSHA)
+			fmt.Fprintf(&b, "git push %s origin %s\n", lease, shellQuote(change.ref))
+		} else {
+			fmt.Fprintf(&b, "git push %s origin %s\n", lease, shellQuote(change.toSHA+":"+change.ref))
+		}
+	}
+	return b.String()
+}
+
+// describeTarget describes the commit a planned change moves a ref to.
+func describeTarget(change plannedChange) string {
+	if change.release == "" {
+		return change.toSHA
+	}
+	return change.toSHA + " (" + change.release + ")"
+}
+
+// shellSafe matches words that need no quoting in a POSIX shell.
+var shellSafe = regexp.MustCompile(`^[A-Za-z0-9@%+=:,./_-]+$`)
+
+// shellQuote quotes s for a POSIX shell unless it is safe as is.
+func shellQuote(s string) string {
+	if shellSafe.MatchString(s) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
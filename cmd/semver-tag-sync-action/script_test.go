@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-github/v90/github"
+)
+
+func TestActionRun_PlanScript(t *testing.T) {
+	mock := verifyMock(t, []*github.RepositoryTag{
+		makeTag("v1.0.0", "sha100"),
+		makeTag("v2.0.0", "sha200"),
+		makeTag("v2.1.0", "sha210"),
+	}, map[string]string{
+		"tags/v1": "sha100",
+		"tags/v2": "sha200",
+	})
+	path := filepath.Join(t.TempDir(), "plan.sh")
+	config := Config{
+		GitHubRepo:        "owner/repo",
+		SyncAllTags:       true,
+		DryRun:            true,
+		PlanScript:        path,
+		SyncMajor:         true,
+		SkipPrereleases:   true,
+		MajorRefType:      refTypeBoth,
+		SupportedMajors:   "v2",
+		UnsupportedMajors: unsupportedDelete,
+	}
+
+	if err := NewAction(mock, config, nil).Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading plan script: %v", err)
+	}
+	want := `#!/bin/sh
+# Floating ref changes planned by semver-tag-sync-action for owner/repo.
+# Run it from an up-to-date clone with push access to origin. A push fails if its
+# ref no longer points where it did when the plan was made.
+set -eu
+
+# Delete tag v1 (at sha100)
+git push --force-with-lease=refs/tags/v1:sha100 origin --delete refs/tags/v1
+
+# Update tag v2 from sha200 to sha210 (v2.1.0)
+git tag -f v2 sha210
+git push --force-with-lease=refs/tags/v2:sha200 origin refs/tags/v2
+
+# Create branch heads/release/v2 at sha210 (v2.1.0)
+git push --force-with-lease=refs/heads/release/v2: origin sha210:refs/heads/release/v2
+`
+	if string(got) != want {
+		t.Errorf("plan script =\n%s\nwant\n%s", got, want)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat plan script: %v", err)
+	}
+	if info.Mode().Perm()&0o100 == 0 {
+		t.Errorf("plan script mode = %v, want it executable", info.Mode())
+	}
+}
+
+func TestActionRun_PlanScriptNothingToDo(t *testing.T) {
+	mock := verifyMock(t, nil, map[string]string{"tags/v1": "abc123", "tags/v1.2": "abc123"})
+	path := filepath.Join(t.TempDir(), "plan.sh")
+	config := Config{
+		GitHubRepo: "owner/repo",
+		GitRef:     "refs/tags/v1.2.3",
+		CommitSHA:  "abc123",
+		SyncMajor:  true,
+		SyncMinor:  true,
+		DryRun:     true,
+		PlanScript: path,
+	}
+
+	if err := NewAction(mock, config, nil).Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading plan script: %v", err)
+	}
+	if want := "set -eu\n\n# Nothing to do, every floating ref is up to date.\n"; len(got) < len(want) || string(got[len(got)-len(want):]) != want {
+		t.Errorf("plan script =\n%s\nwant it to end with\n%s", got, want)
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"refs/tags/v1", "refs/tags/v1"},
+		{"--force-with-lease=refs/tags/v1:", "--force-with-lease=refs/tags/v1:"},
+		{"v1 beta", "'v1 beta'"},
+		{"it's", `'it'\''s'`},
+	}
+	for _, tt := range tests {
+		if got := shellQuote(tt.in); got != tt.want {
+			t.Errorf("shellQuote(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -3,21 +3,26 @@ package main
 import (
 	"fmt"
 	"regexp"
-	"strconv"
 	"strings"
 )
 
 // semverRegex matches semantic versioning tags like v1.2.3, v1.2.3-beta, v1.2.3+build.
-var semverRegex = regexp.MustCompile(`^v(\d+)\.(\d+)\.(\d+)([-+].*)?$`)
+var semverRegex = regexp.MustCompile(`^(v)(\d+)\.(\d+)\.(\d+)([-+].*)?$`)
+
+// fourPartRegex matches four-part version tags like v1.2.3.4, v1.2.3.4-beta.
+var fourPartRegex = regexp.MustCompile(`^(v)(\d+)\.(\d+)\.(\d+)\.(\d+)([-+].*)?$`)
 
 // SemVer represents a parsed semantic version.
 type SemVer struct {
+	Prefix       string // Tag prefix preceding the version numbers (e.g., "v")
 	Major        string
 	Minor        string
 	Patch        string
+	Revision     string // Fourth version component of four-part versions (e.g., "4" for v1.2.3.4)
 	Suffix       string // Prerelease and/or build metadata suffix (e.g., "-beta+build")
 	Full         string
-	IsPrerelease bool // True only if suffix starts with "-" (not for build metadata only)
+	IsPrerelease bool   // True only if suffix starts with "-" (not for build metadata only)
+	Channel      string // Prerelease channel (e.g., "rc"), empty for stable releases
 }
 
 // ParseSemVer parses a semantic version tag and returns its components.
@@ -27,20 +32,61 @@ func ParseSemVer(tag string) (*SemVer, error) {
 		return nil, fmt.Errorf("tag %q does not match semantic versioning format (expected vX.Y.Z)", tag)
 	}
 	suffix := ""
-	if len(matches) > 4 {
-		suffix = matches[4]
+	if len(matches) > 5 {
+		suffix = matches[5]
 	}
 	// Per semver spec: prerelease versions have a hyphen suffix (e.g., -beta, -rc.1)
 	// Build metadata uses + suffix (e.g., +build.123) and is NOT a prerelease
 	isPrerelease := strings.HasPrefix(suffix, "-")
-	return &SemVer{
-		Major:        matches[1],
-		Minor:        matches[2],
-		Patch:        matches[3],
+	sv := &SemVer{
+		Prefix:       matches[1],
+		Major:        matches[2],
+		Minor:        matches[3],
+		Patch:        matches[4],
 		Suffix:       suffix,
 		Full:         tag,
 		IsPrerelease: isPrerelease,
-	}, nil
+	}
+	sv.Channel = prereleaseChannel(sv)
+	return sv, nil
+}
+
+// ParseFourPartVersion parses a four-part version tag like v1.2.3.4 and returns its components.
+func ParseFourPartVersion(tag string) (*SemVer, error) {
+	matches := fourPartRegex.FindStringSubmatch(tag)
+	if matches == nil {
+		return nil, fmt.Errorf("tag %q does not match four-part versioning format (expected vW.X.Y.Z)", tag)
+	}
+	sv := &SemVer{
+		Prefix:       matches[1],
+		Major:        matches[2],
+		Minor:        matches[3],
+		Patch:        matches[4],
+		Revision:     matches[5],
+		Suffix:       matches[6],
+		Full:         tag,
+		IsPrerelease: strings.HasPrefix(matches[6], "-"),
+	}
+	sv.Channel = prereleaseChannel(sv)
+	return sv, nil
+}
+
+// channelRegex matches prerelease channel names that are safe to use in ref names.
+var channelRegex = regexp.MustCompile(`^[0-9A-Za-z-]+$`)
+
+// prereleaseChannel returns the channel of a prerelease (e.g., "rc" for v1.2.3-rc.3),
+// derived from the first prerelease identifier without trailing digits, or "" if there is none.
+func prereleaseChannel(sv *SemVer) string {
+	if !sv.IsPrerelease {
+		return ""
+	}
+	prerelease, _, _ := strings.Cut(strings.TrimPrefix(sv.Suffix, "-"), "+")
+	identifier, _, _ := strings.Cut(prerelease, ".")
+	channel := strings.TrimRight(strings.TrimRight(identifier, "0123456789"), "-")
+	if !channelRegex.MatchString(channel) {
+		return ""
+	}
+	return channel
 }
 
 // MajorTag returns the major version tag (e.g., "v1").
@@ -54,24 +100,64 @@ func (s *SemVer) MinorTag() string {
 }
 
 // SemVerGreaterThan returns true if a represents a higher version than b.
+// Version numbers are compared as arbitrarily large integers.
 func SemVerGreaterThan(a, b *SemVer) bool {
-	aMaj, _ := strconv.Atoi(a.Major)
-	bMaj, _ := strconv.Atoi(b.Major)
-	if aMaj != bMaj {
-		return aMaj > bMaj
-	}
-	aMin, _ := strconv.Atoi(a.Minor)
-	bMin, _ := strconv.Atoi(b.Minor)
-	if aMin != bMin {
-		return aMin > bMin
-	}
-	aPat, _ := strconv.Atoi(a.Patch)
-	bPat, _ := strconv.Atoi(b.Patch)
-	if aPat != bPat {
-		return aPat > bPat
+	for _, c := range []int{
+		compareNumeric(a.Major, b.Major),
+		compareNumeric(a.Minor, b.Minor),
+		compareNumeric(a.Patch, b.Patch),
+		compareNumeric(a.Revision, b.Revision),
+	} {
+		if c != 0 {
+			return c > 0
+		}
 	}
 	if a.IsPrerelease != b.IsPrerelease {
 		return !a.IsPrerelease
 	}
+	if a.IsPrerelease {
+		return comparePrerelease(prereleaseIdentifiers(a), prereleaseIdentifiers(b)) > 0
+	}
 	return false
 }
+
+// prereleaseIdentifiers returns the dot-separated prerelease identifiers of a version.
+func prereleaseIdentifiers(sv *SemVer) []string {
+	prerelease, _, _ := strings.Cut(strings.TrimPrefix(sv.Suffix, "-"), "+")
+	return strings.Split(prerelease, ".")
+}
+
+// comparePrerelease compares prerelease identifiers by semver precedence rules:
+// numeric identifiers compare numerically and rank below alphanumeric ones,
+// and a shorter set of identifiers ranks below a longer one with the same prefix.
+func comparePrerelease(a, b []string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		aNum, bNum := isNumeric(a[i]), isNumeric(b[i])
+		switch {
+		case aNum && bNum:
+			if c := compareNumeric(a[i], b[i]); c != 0 {
+				return c
+			}
+		case aNum:
+			return -1
+		case bNum:
+			return 1
+		default:
+			if c := strings.Compare(a[i], b[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return cmpInt(len(a), len(b))
+}
+
+// cmpInt compares two integers, returning -1, 0 or 1.
+func cmpInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
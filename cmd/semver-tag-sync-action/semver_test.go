@@ -213,6 +213,12 @@ func TestSemVerGreaterThan(t *testing.T) {
 		{"equal", "v1.2.3", "v1.2.3", false},
 		{"stable beats prerelease", "v1.2.3", "v1.2.3-beta", true},
 		{"prerelease loses to stable", "v1.2.3-beta", "v1.2.3", false},
+		{"later rc wins", "v1.2.3-rc.2", "v1.2.3-rc.1", true},
+		{"numeric identifiers compare numerically", "v1.2.3-rc.10", "v1.2.3-rc.9", true},
+		{"alphanumeric identifiers compare lexically", "v1.2.3-rc.1", "v1.2.3-beta.2", true},
+		{"numeric ranks below alphanumeric", "v1.2.3-1", "v1.2.3-alpha", false},
+		{"longer identifier set wins", "v1.2.3-alpha.1", "v1.2.3-alpha", true},
+		{"build metadata is ignored", "v1.2.3-rc.1+b2", "v1.2.3-rc.1+b1", false},
 	}
 
 	for _, tt := range tests {
@@ -225,3 +231,84 @@ func TestSemVerGreaterThan(t *testing.T) {
 		})
 	}
 }
+
+func TestPrereleaseChannel(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want string
+	}{
+		{"v2.0.0-rc.3", "rc"},
+		{"v2.0.0-beta", "beta"},
+		{"v2.0.0-alpha1", "alpha"},
+		{"v2.0.0-rc.1+build.5", "rc"},
+		{"v2.0.0-1", ""},
+		{"v2.0.0-rc~1", ""},
+		{"v2.0.0", ""},
+		{"v2.0.0+build", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			sv, err := ParseSemVer(tt.tag)
+			if err != nil {
+				t.Fatalf("ParseSemVer() error = %v", err)
+			}
+			if got := prereleaseChannel(sv); got != tt.want {
+				t.Errorf("prereleaseChannel(%s) = %q, want %q", tt.tag, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFourPartVersion(t *testing.T) {
+	tests := []struct {
+		tag          string
+		wantRevision string
+		wantPatch    string
+		wantErr      bool
+	}{
+		{"v1.2.3.4", "4", "3", false},
+		{"v1.2.3.4-beta.1", "4", "3", false},
+		{"v10.0.19041.1", "1", "19041", false},
+		{"v1.2.3", "", "", true},
+		{"1.2.3.4", "", "", true},
+		{"v1.2.3.4.5", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			sv, err := ParseFourPartVersion(tt.tag)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFourPartVersion(%q) error = %v, wantErr %v", tt.tag, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if sv.Patch != tt.wantPatch || sv.Revision != tt.wantRevision {
+				t.Errorf("ParseFourPartVersion(%q) patch/revision = %s/%s, want %s/%s", tt.tag, sv.Patch, sv.Revision, tt.wantPatch, tt.wantRevision)
+			}
+		})
+	}
+}
+
+func TestSemVerGreaterThan_FourPart(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"v1.2.3.10", "v1.2.3.9", true},
+		{"v1.2.3.1", "v1.2.3.2", false},
+		{"v1.2.4.0", "v1.2.3.99", true},
+		{"v1.2.3.4", "v1.2.3.4-rc.1", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			a, _ := ParseFourPartVersion(tt.a)
+			b, _ := ParseFourPartVersion(tt.b)
+			if got := SemVerGreaterThan(a, b); got != tt.want {
+				t.Errorf("SemVerGreaterThan(%s, %s) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// versionComponents names the numeric components of a version core in order.
+var versionComponents = []string{"major", "minor", "patch", "revision"}
+
+// ParseStrictSemVer parses a tag like v1.2.3-rc.1+build.5 applying the full SemVer 2.0 grammar.
+func ParseStrictSemVer(tag string) (*SemVer, error) {
+	return parseStrict(tag, 3)
+}
+
+// parseStrict parses a tag with the given number of numeric components, reporting the
+// SemVer 2.0 rule a malformed tag violates.
+func parseStrict(tag string, components int) (*SemVer, error) {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("tag %q is not a valid semantic version: %s", tag, fmt.Sprintf(format, args...))
+	}
+
+	rest, ok := strings.CutPrefix(tag, "v")
+	if !ok {
+		return nil, invalid("missing \"v\" prefix")
+	}
+	rest, build, hasBuild := strings.Cut(rest, "+")
+	core, prerelease, hasPrerelease := strings.Cut(rest, "-")
+
+	numbers := strings.Split(core, ".")
+	if len(numbers) != components {
+		return nil, invalid("version core must have %d dot-separated components, got %d", components, len(numbers))
+	}
+	for i, number := range numbers {
+		name := versionComponents[i]
+		switch {
+		case number == "":
+			return nil, invalid("%s version is empty", name)
+		case !isNumeric(number):
+			return nil, invalid("%s version %q is not a non-negative integer", name, number)
+		case len(number) > 1 && number[0] == '0':
+			return nil, invalid("%s version %q has a leading zero", name, number)
+		}
+	}
+
+	if hasPrerelease {
+		if err := checkIdentifiers("prerelease", prerelease, true); err != nil {
+			return nil, invalid("%s", err)
+		}
+	}
+	if hasBuild {
+		if err := checkIdentifiers("build metadata", build, false); err != nil {
+			return nil, invalid("%s", err)
+		}
+	}
+
+	sv := &SemVer{
+		Prefix:       "v",
+		Major:        numbers[0],
+		Minor:        numbers[1],
+		Patch:        numbers[2],
+		Suffix:       tag[len("v")+len(core):],
+		Full:         tag,
+		IsPrerelease: hasPrerelease,
+	}
+	if components > 3 {
+		sv.Revision = numbers[3]
+	}
+	sv.Channel = prereleaseChannel(sv)
+	return sv, nil
+}
+
+// checkIdentifiers validates dot-separated prerelease or build metadata identifiers.
+// Numeric prerelease identifiers must not have leading zeros; build identifiers may.
+func checkIdentifiers(kind, value string, noLeadingZeros bool) error {
+	if value == "" {
+		return fmt.Errorf("%s is empty", kind)
+	}
+	for i, identifier := range strings.Split(value, ".") {
+		if identifier == "" {
+			return fmt.Errorf("%s identifier %d is empty", kind, i+1)
+		}
+		if strings.Trim(identifier, "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz-") != "" {
+			return fmt.Errorf("%s identifier %q contains characters other than [0-9A-Za-z-]", kind, identifier)
+		}
+		if noLeadingZeros && isNumeric(identifier) && len(identifier) > 1 && identifier[0] == '0' {
+			return fmt.Errorf("numeric %s identifier %q has a leading zero", kind, identifier)
+		}
+	}
+	return nil
+}
+
+// isNumeric reports whether s is a non-empty string of ASCII digits.
+func isNumeric(s string) bool {
+	return s != "" && strings.Trim(s, "0123456789") == ""
+}
+
+// compareNumeric compares two non-negative decimal integers of arbitrary size.
+func compareNumeric(a, b string) int {
+	a = strings.TrimLeft(a, "0")
+	b = strings.TrimLeft(b, "0")
+	if len(a) != len(b) {
+		return cmpInt(len(a), len(b))
+	}
+	return strings.Compare(a, b)
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseStrictSemVer(t *testing.T) {
+	tests := []struct {
+		tag     string
+		wantErr string
+	}{
+		{tag: "v1.2.3"},
+		{tag: "v0.0.0"},
+		{tag: "v1.2.3-rc.1+build.005"},
+		{tag: "v1.2.3-x-y-z.--"},
+		{tag: "v99999999999999999999.0.0"},
+		{tag: "1.2.3", wantErr: `missing "v" prefix`},
+		{tag: "v01.2.3", wantErr: `major version "01" has a leading zero`},
+		{tag: "v1.02.3", wantErr: `minor version "02" has a leading zero`},
+		{tag: "v1.2", wantErr: "version core must have 3 dot-separated components, got 2"},
+		{tag: "v1..3", wantErr: "minor version is empty"},
+		{tag: "v1.2.x", wantErr: `patch version "x" is not a non-negative integer`},
+		{tag: "v1.2.3-", wantErr: "prerelease is empty"},
+		{tag: "v1.2.3-beta..1", wantErr: "prerelease identifier 2 is empty"},
+		{tag: "v1.2.3-rc.01", wantErr: `numeric prerelease identifier "01" has a leading zero`},
+		{tag: "v1.2.3-rc_1", wantErr: `prerelease identifier "rc_1" contains characters other than [0-9A-Za-z-]`},
+		{tag: "v1.2.3+", wantErr: "build metadata is empty"},
+		{tag: "v1.2.3+build..1", wantErr: "build metadata identifier 2 is empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			sv, err := ParseStrictSemVer(tt.tag)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ParseStrictSemVer(%q) error = %v", tt.tag, err)
+				}
+				if sv.Full != tt.tag {
+					t.Errorf("ParseStrictSemVer(%q).Full = %q", tt.tag, sv.Full)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseStrictSemVer(%q) error = %v, want %q", tt.tag, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSemVerGreaterThan_LargeNumbers(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"v99999999999999999999.0.0", "v2.0.0", true},
+		{"v1.0.0", "v99999999999999999999.0.0", false},
+		{"v1.0.0-rc.99999999999999999999", "v1.0.0-rc.2", true},
+		{"v1.0.0-rc.2", "v1.0.0-rc.99999999999999999999", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			a, _ := ParseStrictSemVer(tt.a)
+			b, _ := ParseStrictSemVer(tt.b)
+			if got := SemVerGreaterThan(a, b); got != tt.want {
+				t.Errorf("SemVerGreaterThan(%s, %s) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSemverScheme_Strict(t *testing.T) {
+	lenient, err := newVersionScheme(Config{})
+	if err != nil {
+		t.Fatalf("newVersionScheme() error = %v", err)
+	}
+	strict, err := newVersionScheme(Config{StrictSemVer: true})
+	if err != nil {
+		t.Fatalf("newVersionScheme() error = %v", err)
+	}
+
+	if _, err := lenient.Parse("v01.2.3"); err != nil {
+		t.Errorf("lenient Parse(v01.2.3) error = %v", err)
+	}
+	if _, err := strict.Parse("v01.2.3"); err == nil {
+		t.Error("strict Parse(v01.2.3) succeeded, want error")
+	}
+
+	fourPart, err := newVersionScheme(Config{StrictSemVer: true, FourPartVersions: true})
+	if err != nil {
+		t.Fatalf("newVersionScheme() error = %v", err)
+	}
+	if sv, err := fourPart.Parse("v1.2.3.4"); err != nil || sv.Revision != "4" {
+		t.Errorf("strict four-part Parse(v1.2.3.4) = %+v, %v", sv, err)
+	}
+	if _, err := fourPart.Parse("v1.2.3.04"); err == nil {
+		t.Error("strict four-part Parse(v1.2.3.04) succeeded, want error")
+	}
+
+	if _, err := newVersionScheme(Config{StrictSemVer: true, VersionScheme: schemePEP440}); err == nil {
+		t.Error("newVersionScheme() with strict pep440 succeeded, want error")
+	}
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Actions for the floating refs of majors outside the supported window.
+const (
+	unsupportedKeep    = "keep"    // Leave the floating refs untouched
+	unsupportedArchive = "archive" // Move the floating refs below archivePrefix
+	unsupportedDelete  = "delete"  // Delete the floating refs
+)
+
+// archivePrefix is prepended to the names of archived floating refs, e.g., archive/v1.
+const archivePrefix = "archive/"
+
+// supportedMajors is the window of majors whose floating refs sync-all maintains:
+// either the count most recent majors or an explicit list of majors.
+type supportedMajors struct {
+	count  int
+	majors []string
+}
+
+// parseSupportedMajors parses a number of recent majors such as "3", or a list such as "v2,v3".
+func parseSupportedMajors(value string) (supportedMajors, error) {
+	items := splitList(value)
+	if len(items) == 1 {
+		if n, err := strconv.Atoi(items[0]); err == nil {
+			if n < 1 {
+				return supportedMajors{}, fmt.Errorf("invalid --supported-majors %q (expected a positive number or a list of majors)", value)
+			}
+			return supportedMajors{count: n}, nil
+		}
+	}
+	for _, item := range items {
+		if _, err := strconv.ParseUint(strings.TrimPrefix(item, "v"), 10, 64); err != nil {
+			return supportedMajors{}, fmt.Errorf("invalid --supported-majors %q (expected a positive number or a list of majors like v2,v3)", value)
+		}
+	}
+	return supportedMajors{majors: items}, nil
+}
+
+// unsupportedLines returns the major keys of the per-line groups outside the supported window.
+// With a count, majors above the oldest supported stable major, such as an upcoming major
+// with only prereleases, are always supported.
+func (a *Action) unsupportedLines(groups floatingGroups) map[string]bool {
+	window, _ := parseSupportedMajors(a.config.SupportedMajors)
+	if window.count == 0 && len(window.majors) == 0 {
+		return nil
+	}
+
+	// Find the newest release of every major line.
+	newest := make(map[string]*SemVer)
+	for level, latest := range groups {
+		if level.repoWide() {
+			continue
+		}
+		for _, entry := range latest {
+			key := a.scheme.MajorKey(entry.semver)
+			if current, ok := newest[key]; !ok || a.greaterThan(entry.semver, current) {
+				newest[key] = entry.semver
+			}
+		}
+	}
+
+	unsupported := make(map[string]bool)
+	if len(window.majors) > 0 {
+		for key, sv := range newest {
+			if !slices.ContainsFunc(window.majors, func(major string) bool {
+				return major == key || strings.TrimPrefix(major, "v") == sv.Major
+			}) {
+				unsupported[key] = true
+			}
+		}
+		return unsupported
+	}
+
+	var stable []*SemVer
+	for _, sv := range newest {
+		if !sv.IsPrerelease {
+			stable = append(stable, sv)
+		}
+	}
+	if len(stable) <= window.count {
+		return unsupported
+	}
+	slices.SortFunc(stable, func(x, y *SemVer) int { return a.scheme.Compare(y, x) })
+	oldest := stable[window.count-1]
+	for key, sv := range newest {
+		if a.greaterThan(oldest, sv) {
+			unsupported[key] = true
+		}
+	}
+	return unsupported
+}
+
+// retireLines removes the groups of unsupported majors from groups and keeps, archives or deletes
+// their floating refs as configured, returning any errors encountered.
+func (a *Action) retireLines(ctx context.Context, owner, repo string, groups floatingGroups) []error {
+	unsupported := a.unsupportedLines(groups)
+	if len(unsupported) == 0 {
+		return nil
+	}
+
+	action := valueOrDefault(a.config.UnsupportedMajors, unsupportedKeep)
+	for _, key := range slices.Sorted(maps.Keys(unsupported)) {
+		a.log.Info("Major version line is out of support",
+			slog.String("major", key),
+			slog.String("action", action),
+		)
+	}
+
+	var errs []error
+	for _, level := range a.levels() {
+		if level.repoWide() {
+			continue
+		}
+		for _, key := range slices.Sorted(maps.Keys(groups[level])) {
+			entry := groups[level][key]
+			if !unsupported[a.scheme.MajorKey(entry.semver)] {
+				continue
+			}
+			delete(groups[level], key)
+			if action == unsupportedKeep {
+				continue
+			}
+			for _, ref := range a.floatingRefs(level, entry.semver) {
+				if err := a.retireRef(ctx, owner, repo, ref, action); err != nil {
+					a.log.Error("Failed to "+action+" "+refKind(ref),
+						slog.String(refKind(ref), refDisplayName(ref)),
+						slog.String("error", err.Error()),
+					)
+					errs = append(errs, fmt.Errorf("failed to %s %s %s: %w", action, refKind(ref), refDisplayName(ref), err))
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// retireRef archives or deletes a floating ref of an unsupported major unless it is frozen or pinned.
+func (a *Action) retireRef(ctx context.Context, owner, repo, ref, action string) error {
+	if reason := a.holdReason(ref, ""); reason != "" {
+		a.logHeld(ref, reason)
+		return nil
+	}
+	if action == unsupportedArchive {
+		current, resp, err := a.client.GetRef(ctx, owner, repo, ref)
+		if err != nil {
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				return nil
+			}
+			return fmt.Errorf("failed to get %s: %w", refDisplayName(ref), err)
+		}
+		namespace, name, _ := strings.Cut(ref, "/")
+		if err := a.syncRefToSHA(ctx, owner, repo, namespace+"/"+archivePrefix+name, current.GetObject().GetSHA(), ""); err != nil {
+			return err
+		}
+	}
+	return a.deleteRef(ctx, owner, repo, ref)
+}
+
+// validateUnsupportedMajors checks the supported majors window and the action for majors outside it.
+func validateUnsupportedMajors(supported, action string) error {
+	if supported != "" {
+		if _, err := parseSupportedMajors(supported); err != nil {
+			return err
+		}
+	}
+	switch action {
+	case "", unsupportedKeep, unsupportedArchive, unsupportedDelete:
+		return nil
+	default:
+		return fmt.Errorf("invalid --unsupported-majors %q (expected %s, %s or %s)", action, unsupportedKeep, unsupportedArchive, unsupportedDelete)
+	}
+}
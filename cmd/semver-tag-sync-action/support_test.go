@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v90/github"
+)
+
+func TestActionRunAll_SupportedMajors(t *testing.T) {
+	tests := []struct {
+		name        string
+		supported   string
+		action      string
+		wantCreated []string
+		wantUpdated []string
+		wantDeleted []string
+	}{
+		{
+			name:        "recent majors leave older lines untouched",
+			supported:   "2",
+			wantUpdated: []string{"tags/v2=sha210", "tags/v3=sha300"},
+		},
+		{
+			name:        "explicit list",
+			supported:   "v1,v3",
+			wantUpdated: []string{"tags/v1=sha150", "tags/v3=sha300"},
+		},
+		{
+			name:        "delete unsupported lines",
+			supported:   "2",
+			action:      unsupportedDelete,
+			wantUpdated: []string{"tags/v2=sha210", "tags/v3=sha300"},
+			wantDeleted: []string{"tags/v1"},
+		},
+		{
+			name:        "archive unsupported lines",
+			supported:   "2",
+			action:      unsupportedArchive,
+			wantCreated: []string{"refs/tags/archive/v1=old"},
+			wantUpdated: []string{"tags/v2=sha210", "tags/v3=sha300"},
+			wantDeleted: []string{"tags/v1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created, updated, deleted []string
+			mock := &mockGitHubClient{
+				listTagsFunc: func(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryTag, *github.Response, error) {
+					return []*github.RepositoryTag{
+						makeTag("v1.5.0", "sha150"),
+						makeTag("v2.1.0", "sha210"),
+						makeTag("v3.0.0", "sha300"),
+						makeTag("v4.0.0-rc.1", "sha400rc1"),
+					}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+				},
+				getRefFunc: func(ctx context.Context, owner, repo, ref string) (*github.Reference, *github.Response, error) {
+					if strings.HasPrefix(ref, "tags/"+archivePrefix) {
+						resp := &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}
+						return nil, resp, &github.ErrorResponse{Response: resp.Response}
+					}
+					return &github.Reference{
+						Object: &github.GitObject{SHA: github.Ptr("old")},
+					}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+				},
+				createRefFunc: func(ctx context.Context, owner, repo string, ref github.CreateRef) (*github.Reference, *github.Response, error) {
+					created = append(created, ref.Ref+"="+ref.SHA)
+					return &github.Reference{}, &github.Response{Response: &http.Response{StatusCode: http.StatusCreated}}, nil
+				},
+				updateRefFunc: func(ctx context.Context, owner, repo, ref string, updateRef github.UpdateRef) (*github.Reference, *github.Response, error) {
+					updated = append(updated, ref+"="+updateRef.SHA)
+					return &github.Reference{}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+				},
+				deleteRefFunc: func(ctx context.Context, owner, repo, ref string) (*github.Response, error) {
+					deleted = append(deleted, ref)
+					return &github.Response{Response: &http.Response{StatusCode: http.StatusNoContent}}, nil
+				},
+			}
+
+			config := Config{
+				GitHubRepo:        "owner/repo",
+				SyncAllTags:       true,
+				SyncMajor:         true,
+				SkipPrereleases:   true,
+				SupportedMajors:   tt.supported,
+				UnsupportedMajors: tt.action,
+			}
+
+			action := NewAction(mock, config, nil)
+			if err := action.Run(context.Background()); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+
+			slices.Sort(updated)
+			if !slices.Equal(created, tt.wantCreated) {
+				t.Errorf("created refs = %v, want %v", created, tt.wantCreated)
+			}
+			if !slices.Equal(updated, tt.wantUpdated) {
+				t.Errorf("updated refs = %v, want %v", updated, tt.wantUpdated)
+			}
+			if !slices.Equal(deleted, tt.wantDeleted) {
+				t.Errorf("deleted refs = %v, want %v", deleted, tt.wantDeleted)
+			}
+		})
+	}
+}
+
+func TestParseSupportedMajors(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    supportedMajors
+		wantErr bool
+	}{
+		{value: "3", want: supportedMajors{count: 3}},
+		{value: "v2,v3", want: supportedMajors{majors: []string{"v2", "v3"}}},
+		{value: "2,3", want: supportedMajors{majors: []string{"2", "3"}}},
+		{value: "v2", want: supportedMajors{majors: []string{"v2"}}},
+		{value: "0", wantErr: true},
+		{value: "latest", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseSupportedMajors(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSupportedMajors(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if got.count != tt.want.count || !slices.Equal(got.majors, tt.want.majors) {
+				t.Errorf("parseSupportedMajors(%q) = %+v, want %+v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Output formats of the verify mode selectable with --verify-format.
+const (
+	verifyFormatText        = "text"        // One line per mismatch
+	verifyFormatJSON        = "json"        // A JSON report of all mismatches
+	verifyFormatAnnotations = "annotations" // GitHub Actions error annotations
+)
+
+// Statuses of a floating ref that does not point to its expected release.
+const (
+	driftStale   = "stale"   // The ref points to another commit
+	driftMissing = "missing" // The ref does not exist
+)
+
+// errDrift is returned by the verify mode when floating refs are out of sync.
+var errDrift = errors.New("floating refs are out of sync")
+
+// driftEntry describes a floating ref that does not point to its expected release.
+type driftEntry struct {
+	Ref         string `json:"ref"`
+	Kind        string `json:"kind"`
+	Name        string `json:"name"`
+	Status      string `json:"status"`
+	ActualSHA   string `json:"actual_sha,omitempty"`
+	ExpectedSHA string `json:"expected_sha"`
+	Release     string `json:"release"`
+}
+
+// verifyReport is the JSON output of the verify mode.
+type verifyReport struct {
+	Checked int          `json:"checked"`
+	Drift   []driftEntry `json:"drift"`
+}
+
+// runVerify checks that every floating ref points to the release sync-all would point it to,
+// reporting each mismatch without changing anything.
+func (a *Action) runVerify(ctx context.Context) error {
+	a.log.Info("Verifying floating refs",
+		slog.String("repo", a.config.GitHubRepo),
+		slog.String("format", valueOrDefault(a.config.VerifyFormat, verifyFormatText)),
+	)
+
+	owner, repo, err := parseRepository(a.config.GitHubRepo)
+	if err != nil {
+		return err
+	}
+
+	checked, drift, err := a.verify(ctx, owner, repo)
+	if err != nil {
+		return err
+	}
+	if err := a.printDrift(checked, drift); err != nil {
+		return err
+	}
+
+	var errs []error
+	if len(drift) > 0 {
+		errs = append(errs, fmt.Errorf("%w: %d of %d floating refs", errDrift, len(drift), checked))
+	}
+	if a.config.DriftIssue {
+		if err := a.syncDriftIssue(ctx, owner, repo, drift); err != nil {
+			a.log.Error("Failed to report drift in an issue",
+				slog.String("error", err.Error()),
+			)
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	a.log.Info("All floating refs are in sync",
+		slog.Int("checked", checked),
+	)
+	return nil
+}
+
+// verify compares every floating ref maintained by sync-all with its expected release, returning
+// the number of refs checked and the mismatches. Frozen refs and unsupported majors are skipped.
+func (a *Action) verify(ctx context.Context, owner, repo string) (int, []driftEntry, error) {
+	groups, err := a.collectLatestTags(ctx, owner, repo)
+	if err != nil {
+		return 0, nil, err
+	}
+	unsupported := a.unsupportedLines(groups)
+
+	checked := 0
+	var drift []driftEntry
+	var errs []error
+	for _, level := range a.levels() {
+		tagMap := groups[level]
+		for _, key := range slices.Sorted(maps.Keys(tagMap)) {
+			if !level.repoWide() && unsupported[a.scheme.MajorKey(tagMap[key].semver)] {
+				continue
+			}
+			for _, ref := range a.floatingRefs(level, tagMap[key].semver) {
+				entry, err := a.targetRelease(ref, tagMap[key])
+				if err != nil {
+					errs = append(errs, fmt.Errorf("failed to verify %s %s %s: %w", level, refKind(ref), refDisplayName(ref), err))
+					continue
+				}
+				if reason := a.holdReason(ref, entry.semver.Full); reason != "" {
+					a.logHeld(ref, reason)
+					continue
+				}
+
+				checked++
+				d, err := a.checkRef(ctx, owner, repo, ref, entry)
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				if d != nil {
+					drift = append(drift, *d)
+				}
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return 0, nil, errors.Join(errs...)
+	}
+	return checked, drift, nil
+}
+
+// checkRef compares a floating ref with its expected release, returning nil if it is in sync.
+func (a *Action) checkRef(ctx context.Context, owner, repo, ref string, entry *tagWithSHA) (*driftEntry, error) {
+	kind := refKind(ref)
+	d := &driftEntry{
+		Ref:         ref,
+		Kind:        kind,
+		Name:        refDisplayName(ref),
+		ExpectedSHA: entry.sha,
+		Release:     entry.semver.Full,
+	}
+
+	current, exists, err := a.currentTarget(ctx, owner, repo, ref)
+	switch {
+	case err != nil:
+		return nil, fmt.Errorf("failed to get %s %s: %w", kind, d.Name, err)
+	case !exists:
+		d.Status = driftMissing
+	case current == entry.sha:
+		a.log.Debug(capitalize(kind)+" is in sync",
+			slog.String(kind, d.Name),
+			slog.String("commit_sha", entry.sha),
+		)
+		return nil, nil
+	default:
+		d.Status = driftStale
+		d.ActualSHA = current
+	}
+
+	a.log.Warn(capitalize(kind)+" is out of sync",
+		slog.String(kind, d.Name),
+		slog.String("status", d.Status),
+		slog.String("actual_sha", d.ActualSHA),
+		slog.String("expected_sha", d.ExpectedSHA),
+		slog.String("release", d.Release),
+	)
+	return d, nil
+}
+
+// printDrift writes the mismatches found by verify in the configured format.
+func (a *Action) printDrift(checked int, drift []driftEntry) error {
+	switch valueOrDefault(a.config.VerifyFormat, verifyFormatText) {
+	case verifyFormatJSON:
+		enc := json.NewEncoder(a.out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(verifyReport{Checked: checked, Drift: append([]driftEntry{}, drift...)})
+	case verifyFormatAnnotations:
+		for _, d := range drift {
+			fmt.Fprintf(a.out, "::error title=Floating %s out of sync::%s\n", d.Kind, escapeAnnotation(d.message()))
+		}
+	default:
+		for _, d := range drift {
+			fmt.Fprintln(a.out, d.message())
+		}
+	}
+	return nil
+}
+
+// message describes the mismatch in a single line.
+func (d driftEntry) message() string {
+	if d.Status == driftMissing {
+		return fmt.Sprintf("%s %s is missing, expected %s (%s)", d.Kind, d.Name, d.ExpectedSHA, d.Release)
+	}
+	return fmt.Sprintf("%s %s points to %s, expected %s (%s)", d.Kind, d.Name, d.ActualSHA, d.ExpectedSHA, d.Release)
+}
+
+// escapeAnnotation escapes the characters with a special meaning in workflow command messages.
+func escapeAnnotation(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// validateVerifyFormat checks the output format of the verify mode.
+func validateVerifyFormat(format string) error {
+	switch format {
+	case "", verifyFormatText, verifyFormatJSON, verifyFormatAnnotations:
+		return nil
+	default:
+		return fmt.Errorf("invalid --verify-format %q (expected %s, %s or %s)", format, verifyFormatText, verifyFormatJSON, verifyFormatAnnotations)
+	}
+}
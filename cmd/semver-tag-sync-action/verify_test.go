@@ -0,0 +1,285 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v90/github"
+)
+
+// verifyMock serves the given release tags and floating refs, failing the test on any write.
+func verifyMock(t *testing.T, tags []*github.RepositoryTag, refs map[string]string) *mockGitHubClient {
+	t.Helper()
+	return &mockGitHubClient{
+		listTagsFunc: func(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryTag, *github.Response, error) {
+			return tags, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+		},
+		getRefFunc: func(ctx context.Context, owner, repo, ref string) (*github.Reference, *github.Response, error) {
+			sha, ok := refs[ref]
+			if !ok {
+				resp := &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}
+				return nil, resp, &github.ErrorResponse{Response: resp.Response}
+			}
+			return &github.Reference{
+				Object: &github.GitObject{SHA: github.Ptr(sha)},
+			}, &github.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+		},
+		createRefFunc: func(ctx context.Context, owner, repo string, ref github.CreateRef) (*github.Reference, *github.Response, error) {
+			t.Errorf("verify created ref %s", ref.Ref)
+			return nil, nil, errors.New("unexpected write")
+		},
+		updateRefFunc: func(ctx context.Context, owner, repo, ref string, updateRef github.UpdateRef) (*github.Reference, *github.Response, error) {
+			t.Errorf("verify updated ref %s", ref)
+			return nil, nil, errors.New("unexpected write")
+		},
+		deleteRefFunc: func(ctx context.Context, owner, repo, ref string) (*github.Response, error) {
+			t.Errorf("verify deleted ref %s", ref)
+			return nil, errors.New("unexpected write")
+		},
+	}
+}
+
+func TestActionRunVerify(t *testing.T) {
+	tags := []*github.RepositoryTag{
+		makeTag("v1.0.0", "sha100"),
+		makeTag("v1.1.0", "sha110"),
+		makeTag("v2.0.0", "sha200"),
+	}
+	refs := map[string]string{
+		"tags/v1":   "sha100",
+		"tags/v1.0": "sha100",
+		"tags/v2":   "sha200",
+		"tags/v2.0": "sha200",
+	}
+
+	tests := []struct {
+		name    string
+		format  string
+		want    []string
+		notWant []string
+	}{
+		{
+			name:   "text",
+			format: verifyFormatText,
+			want: []string{
+				"tag v1 points to sha100, expected sha110 (v1.1.0)\n",
+				"tag v1.1 is missing, expected sha110 (v1.1.0)\n",
+			},
+			notWant: []string{"v2"},
+		},
+		{
+			name:   "annotations",
+			format: verifyFormatAnnotations,
+			want: []string{
+				"::error title=Floating tag out of sync::tag v1 points to sha100, expected sha110 (v1.1.0)\n",
+				"::error title=Floating tag out of sync::tag v1.1 is missing, expected sha110 (v1.1.0)\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := Config{
+				GitHubRepo:      "owner/repo",
+				Verify:          true,
+				VerifyFormat:    tt.format,
+				SyncMajor:       true,
+				SyncMinor:       true,
+				SkipPrereleases: true,
+			}
+			var out bytes.Buffer
+			action := NewAction(verifyMock(t, tags, refs), config, nil)
+			action.out = &out
+
+			err := action.Run(context.Background())
+			if !errors.Is(err, errDrift) {
+				t.Fatalf("Run() error = %v, want %v", err, errDrift)
+			}
+			if !strings.Contains(err.Error(), "2 of 5") {
+				t.Errorf("Run() error = %v, want it to count 2 of 5 refs", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("output = %q, want it to contain %q", out.String(), want)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(out.String(), notWant) {
+					t.Errorf("output = %q, want it not to contain %q", out.String(), notWant)
+				}
+			}
+		})
+	}
+}
+
+func TestActionRunVerify_JSON(t *testing.T) {
+	tags := []*github.RepositoryTag{
+		makeTag("v1.0.0", "sha100"),
+		makeTag("v1.1.0", "sha110"),
+	}
+	config := Config{
+		GitHubRepo:      "owner/repo",
+		Verify:          true,
+		VerifyFormat:    verifyFormatJSON,
+		SyncMajor:       true,
+		SkipPrereleases: true,
+	}
+	var out bytes.Buffer
+	action := NewAction(verifyMock(t, tags, map[string]string{"tags/v1": "sha100"}), config, nil)
+	action.out = &out
+
+	if err := action.Run(context.Background()); !errors.Is(err, errDrift) {
+		t.Fatalf("Run() error = %v, want %v", err, errDrift)
+	}
+
+	var report verifyReport
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+	}
+	want := driftEntry{
+		Ref:         "tags/v1",
+		Kind:        "tag",
+		Name:        "v1",
+		Status:      driftStale,
+		ActualSHA:   "sha100",
+		ExpectedSHA: "sha110",
+		Release:     "v1.1.0",
+	}
+	if report.Checked != 1 || len(report.Drift) != 1 || report.Drift[0] != want {
+		t.Errorf("report = %+v, want 1 checked and drift %+v", report, want)
+	}
+}
+
+// runWithProcessOutput runs the action with the logger of the binary and the process stdout and
+// stderr redirected, returning what was written to each.
+func runWithProcessOutput(t *testing.T, client GitHubClient, config Config) (stdout, stderr string, err error) {
+	t.Helper()
+	capture := func(f **os.File) (restore func() string) {
+		r, w, pipeErr := os.Pipe()
+		if pipeErr != nil {
+			t.Fatal(pipeErr)
+		}
+		saved := *f
+		*f = w
+		done := make(chan string)
+		go func() {
+			data, _ := io.ReadAll(r)
+			done <- string(data)
+		}()
+		return func() string {
+			*f = saved
+			_ = w.Close()
+			return <-done
+		}
+	}
+	restoreStdout := capture(&os.Stdout)
+	restoreStderr := capture(&os.Stderr)
+	err = NewAction(client, config, setupLogger("debug")).Run(context.Background())
+	return restoreStdout(), restoreStderr(), err
+}
+
+func TestActionRunVerify_JSONWithLogs(t *testing.T) {
+	tags := []*github.RepositoryTag{
+		makeTag("v1.0.0", "sha100"),
+		makeTag("v1.1.0", "sha110"),
+	}
+	config := Config{
+		GitHubRepo:      "owner/repo",
+		Verify:          true,
+		VerifyFormat:    verifyFormatJSON,
+		SyncMajor:       true,
+		SkipPrereleases: true,
+	}
+
+	stdout, stderr, err := runWithProcessOutput(t, verifyMock(t, tags, map[string]string{"tags/v1": "sha100"}), config)
+	if !errors.Is(err, errDrift) {
+		t.Fatalf("Run() error = %v, want %v", err, errDrift)
+	}
+	var report verifyReport
+	if err := json.Unmarshal([]byte(stdout), &report); err != nil {
+		t.Fatalf("stdout is not JSON: %v\n%s", err, stdout)
+	}
+	if len(report.Drift) != 1 {
+		t.Errorf("report = %+v, want 1 drift entry", report)
+	}
+	if !strings.Contains(stderr, "Fetched all tags") {
+		t.Errorf("stderr = %q, want the log lines", stderr)
+	}
+}
+
+func TestActionRunVerify_InSync(t *testing.T) {
+	tags := []*github.RepositoryTag{
+		makeTag("v1.0.0", "sha100"),
+		makeTag("v1.1.0", "sha110"),
+		makeTag("v2.0.0", "sha200"),
+		makeTag("v3.0.0", "sha300"),
+	}
+	refs := map[string]string{
+		"tags/v1": "sha100", // Pinned to v1.0.0
+		"tags/v2": "manual", // Frozen
+		"tags/v3": "sha300",
+	}
+	config := Config{
+		GitHubRepo:      "owner/repo",
+		Verify:          true,
+		VerifyFormat:    verifyFormatJSON,
+		SyncMajor:       true,
+		SkipPrereleases: true,
+		FrozenTags:      []string{"v2"},
+		Pins:            map[string]string{"v1": "v1.0.0"},
+	}
+	var out bytes.Buffer
+	action := NewAction(verifyMock(t, tags, refs), config, nil)
+	action.out = &out
+
+	if err := action.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if got, want := out.String(), "{\n  \"checked\": 2,\n  \"drift\": []\n}\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestActionRunVerify_UnsupportedMajors(t *testing.T) {
+	tags := []*github.RepositoryTag{
+		makeTag("v1.0.0", "sha100"),
+		makeTag("v2.0.0", "sha200"),
+	}
+	config := Config{
+		GitHubRepo:      "owner/repo",
+		Verify:          true,
+		SyncMajor:       true,
+		SkipPrereleases: true,
+		SupportedMajors: "1",
+	}
+	action := NewAction(verifyMock(t, tags, map[string]string{"tags/v2": "sha200"}), config, nil)
+	action.out = &bytes.Buffer{}
+
+	if err := action.Run(context.Background()); err != nil {
+		t.Errorf("Run() error = %v, want the unsupported v1 line to be skipped", err)
+	}
+}
+
+func TestValidateVerifyFormat(t *testing.T) {
+	for _, format := range []string{"", verifyFormatText, verifyFormatJSON, verifyFormatAnnotations} {
+		if err := validateVerifyFormat(format); err != nil {
+			t.Errorf("validateVerifyFormat(%q) error = %v", format, err)
+		}
+	}
+	if err := validateVerifyFormat("xml"); err == nil {
+		t.Error("validateVerifyFormat(\"xml\") expected error")
+	}
+}
+
+func TestEscapeAnnotation(t *testing.T) {
+	if got, want := escapeAnnotation("50%\nnext\r"), "50%25%0Anext%0D"; got != want {
+		t.Errorf("escapeAnnotation() = %q, want %q", got, want)
+	}
+}
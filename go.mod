@@ -2,6 +2,9 @@ module github.com/cbrgm/semver-tag-sync-action
 
 go 1.26.5
 
-require github.com/google/go-github/v90 v90.0.0
+require (
+	github.com/google/go-github/v90 v90.0.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require github.com/google/go-querystring v1.2.0 // indirect